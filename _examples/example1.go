@@ -22,7 +22,7 @@ func main() {
 
 	var isMarmiteNice bool
 	var isVerbose bool
-	var name = "World"
+	var name string
 	var favNum int
 
 	application.AddGlobalOption(console.OptionDefinition{
@@ -46,10 +46,11 @@ func main() {
 			})
 
 			definition.AddOption(console.OptionDefinition{
-				Value:  parameters.NewStringValue(&name),
-				Spec:   "-n, --name=NAME",
-				Desc:   "Provide a name for the greeting.",
-				EnvVar: "EXAMPLE_NAME",
+				Value:   parameters.NewStringValue(&name),
+				Spec:    "-n, --name=NAME",
+				Desc:    "Provide a name for the greeting.",
+				EnvVar:  "EXAMPLE_NAME",
+				Default: "World",
 			})
 
 			definition.AddArgument(console.ArgumentDefinition{
@@ -67,6 +68,8 @@ func main() {
 		},
 	})
 
+	application.AddCommand(console.NewCompletionCommand(application))
+
 	code := application.Run(os.Args[1:], os.Environ())
 
 	os.Exit(code)
@@ -1,13 +1,21 @@
 package console
 
 import (
+	"context"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/eidolon/console/format"
 	"github.com/eidolon/console/parameters"
+	"github.com/eidolon/console/style"
 )
 
+// explainValuesOptionName is the name of the global option used to show where values were set from,
+// instead of running a command.
+const explainValuesOptionName = "explain-values"
+
 // Application represents the heart of the console application. It is what orchestrates running
 // commands, initiates input parsing, mapping, and validation; and will handle failure for each of
 // those tasks.
@@ -24,11 +32,50 @@ type Application struct {
 	Help string
 	// Writer to write output to.
 	Writer io.Writer
+	// Writer to write errors, warnings, and other diagnostics to.
+	ErrorWriter io.Writer
+	// Prefix used to derive environment variable names for options with long names, from the
+	// command path and the long option name, e.g. `MYAPP_GREET_NAME`. Disabled if empty.
+	EnvPrefix string
+	// Name of the directory that config files are looked for in, inside of each XDG config
+	// directory, e.g. `myapp` for `~/.config/myapp/config.yaml`. Enables the `--config` option.
+	ConfigName string
+	// Environment variable that may hold the path to a config file. Enables the `--config` option.
+	ConfigEnvVar string
+	// Name of the format that Output.Render uses by default, e.g. "table". Enables the `--output`
+	// option, to choose another format, and the `--format` option, to give a Go template instead.
+	OutputFormat string
+	// Whether or not markup in output is rendered as colors and styles, see the style package. Enables
+	// the `--color` option, to choose when colors and styles are used. If disabled, output is written
+	// as it is.
+	Markup bool
+	// Whether or not the `--explain-values` option is added, to show the value of each option and
+	// argument, and where it was set from, instead of running a command. Hidden options and arguments
+	// are left out, and values set from the environment or config files are masked.
+	ExplainValues bool
+	// Whether or not unknown options, and more arguments than are defined, are errors when any
+	// command is run. Strict mode may also be enabled on individual commands.
+	Strict bool
+	// Function called for each deprecated command, argument, or option that is used, before the
+	// command is executed. Defaults to printing a warning to the error writer.
+	OnDeprecated DeprecationFunc
+	// Function to run before any command is executed, before the hooks of the command, or any of it's
+	// ancestors.
+	PersistentPreRun PreRunFunc
+	// Function to run after any command is executed, after the hooks of the command, and all of it's
+	// ancestors.
+	PersistentPostRun PostRunFunc
 
 	// Slice of commands that can be run. May contain sub-commands.
 	commands []*Command
 	// Slice of global options.
 	globalOptionDefinitions []OptionDefinition
+	// Slice of middleware, wrapping the execution of every command, outermost first.
+	middleware []Middleware
+	// Renderer for markup in output, if any styles have been added.
+	styles *style.Renderer
+	// Formatters added to the built-in ones, keyed by name.
+	outputFormatters map[string]format.Formatter
 	// Application definition
 	definition *Definition
 	// Application input.
@@ -42,16 +89,25 @@ type Application struct {
 // NewApplication creates a new Application with some sane defaults.
 func NewApplication(name string, version string) *Application {
 	return &Application{
-		Name:       name,
-		UsageName:  filepath.Base(os.Args[0]),
-		Version:    version,
-		Writer:     os.Stdout,
-		definition: NewDefinition(),
+		Name:         name,
+		UsageName:    filepath.Base(os.Args[0]),
+		Version:      version,
+		Writer:       os.Stdout,
+		ErrorWriter:  os.Stderr,
+		OnDeprecated: printDeprecationWarning,
+		definition:   NewDefinition(),
 	}
 }
 
-// Run runs the configured application, with the given input.
+// Run runs the configured application, with the given input. Commands are given a context that is
+// cancelled when the application receives SIGINT or SIGTERM.
 func (a *Application) Run(argv []string, env []string) int {
+	return a.RunContext(context.Background(), argv, env)
+}
+
+// RunContext runs the configured application, with the given input, the same way that Run does. The
+// context given to commands is derived from ctx.
+func (a *Application) RunContext(ctx context.Context, argv []string, env []string) int {
 	if a.definition == nil {
 		panic("attempted to start application with nil definition")
 	}
@@ -59,6 +115,23 @@ func (a *Application) Run(argv []string, env []string) int {
 	// Set output at runtime, so that it's available for everything else that could use it, and
 	// up-to-date with what the user has requested their io.Writer to be.
 	a.output = NewOutput(a.Writer)
+	a.output.ErrorWriter = a.ErrorWriter
+	a.output.Format = a.OutputFormat
+	a.output.Markup = a.Markup
+	a.output.env = parseEnv(env)
+
+	if a.styles != nil {
+		a.output.styles = a.styles
+	}
+
+	for name, formatter := range a.outputFormatters {
+		a.output.AddFormatter(name, formatter)
+	}
+
+	// Shell completion scripts call back into the application to find candidates.
+	if len(argv) > 0 && argv[0] == completeCommandName {
+		return a.complete(argv[1:])
+	}
 
 	a.configure(a.definition)
 
@@ -66,32 +139,84 @@ func (a *Application) Run(argv []string, env []string) int {
 	// useful for the `help` argument because we need to know the context (i.e. cmd) we're
 	// running to show the right thing.
 	cmd, path := a.resolveCommand(argv)
-	if cmd != nil && cmd.Configure != nil {
-		cmd.Configure(a.definition)
-	}
+	a.configureCommand(a.definition, cmd, path)
 
 	// Trim argv so that the command path is not left in and sent to commands.
 	argv = argv[len(path):]
 
-	if a.hasHelpOption(argv) || (cmd == nil || cmd.Execute == nil) {
+	// Assign input to application.
+	a.input = ParseInput2(a.definition, argv)
+
+	// Colors are chosen before anything is written, so that help and errors use them too. The choice
+	// may still be changed by the environment, or config files, once input is mapped.
+	if a.Markup {
+		a.output.Color = ColorMode(a.input.GetOptionValue([]string{colorOptionName}))
+	}
+
+	if err := a.findUnknownCommand(cmd, path, argv); err != nil {
+		a.output.Eprintln(a.output.escape(err.Error()))
+		a.output.Eprintf("Try '%s --help' for more information.\n", strings.Join(append([]string{a.UsageName}, path...), " "))
+		return 102
+	}
+
+	if a.hasHelpOption(argv) || (cmd == nil || !cmd.executable()) {
 		a.showHelp(cmd, path)
 		return 100
 	}
 
-	// Assign input to application.
-	a.input = ParseInput2(a.definition, argv)
+	var err error
+
+	a.input.Config, err = a.loadConfig(path, env)
+	if err == nil {
+		err = MapInput2(a.definition, a.input, env)
+	}
+
+	if a.Markup {
+		a.applyColor()
+	}
+
+	if a.Strict || cmd.Strict {
+		err = appendMappingErrors(CheckUnknownInput(a.definition, a.input), err)
+	}
+
+	if err == nil {
+		err = a.applyOutputFormat()
+	}
+
+	if err == nil && a.ExplainValues && a.input.HasOption([]string{explainValuesOptionName}) {
+		a.output.Print(a.output.escape(DescribeValues(a.definition, false)))
+		return 0
+	}
+
+	if err == nil && cmd.Validate != nil {
+		err = cmd.Validate(a.input)
+	}
 
-	err := MapInput(a.definition, a.input, env)
 	if err != nil {
-		a.output.Println(err)
-		a.output.Printf("Try '%s --help' for more information.\n", a.UsageName)
+		a.output.Eprintln(a.output.escape(err.Error()))
+		a.output.Eprintf("Try '%s --help' for more information.\n", a.UsageName)
 		return 101
 	}
 
-	err = cmd.Execute(a.input, a.output)
+	if a.OnDeprecated != nil {
+		for _, warning := range a.findDeprecations(a.definition, path) {
+			a.OnDeprecated(a.output, warning)
+		}
+	}
+
+	ctx, signals := handleSignals(ctx)
+	defer signals.stop()
+
+	err = a.runCommand(ctx, cmd, path, a.input, a.output)
+
+	// Commands that are interrupted are expected to stop early, so any error is likely just that.
+	if sig := signals.received(); sig != nil {
+		return signalExitCode(sig)
+	}
+
 	if err != nil {
-		a.output.Println(err)
-		a.output.Printf("Try '%s %s --help' for more information.\n", a.UsageName, cmd.Name)
+		a.output.Eprintln(a.output.escape(err.Error()))
+		a.output.Eprintf("Try '%s %s --help' for more information.\n", a.UsageName, cmd.Name)
 		return 1
 	}
 
@@ -117,6 +242,12 @@ func (a *Application) AddGlobalOption(definition OptionDefinition) {
 	a.globalOptionDefinitions = append(a.globalOptionDefinitions, definition)
 }
 
+// AddMiddleware adds middleware that wraps the execution of every command. Middleware added first
+// is run outermost.
+func (a *Application) AddMiddleware(middleware Middleware) {
+	a.middleware = append(a.middleware, middleware)
+}
+
 // resolveCommand attempts to find the command to run based on the raw input.
 func (a *Application) resolveCommand(args []string) (*Command, []string) {
 	var loop func(depth int, container CommandContainer) *Command
@@ -154,6 +285,88 @@ func (a *Application) resolveCommand(args []string) (*Command, []string) {
 	return loop(0, a), path
 }
 
+// findUnknownCommand checks whether the first of the remaining raw input, after the command path,
+// was meant to be a command that doesn't exist. Commands that can be executed take the remaining
+// input as arguments instead, so only the application and commands that can't be executed are
+// checked. The returned error suggests similarly named commands that aren't hidden, if any.
+func (a *Application) findUnknownCommand(cmd *Command, path []string, args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return nil
+	}
+
+	var container CommandContainer = a
+	if cmd != nil {
+		if cmd.executable() {
+			return nil
+		}
+
+		container = cmd
+	}
+
+	var candidates []string
+	for _, sub := range visibleCommands(container.Commands()) {
+		candidates = append(candidates, sub.Name)
+
+		if sub.Alias != "" {
+			candidates = append(candidates, sub.Alias)
+		}
+	}
+
+	return &UnknownCommandError{
+		Name:        args[0],
+		Path:        path,
+		Suggestions: suggest(args[0], candidates),
+	}
+}
+
+// findCommandsOnPath finds each of the commands along the given command path, in order.
+func (a *Application) findCommandsOnPath(path []string) []*Command {
+	var commands []*Command
+
+	var container CommandContainer = a
+	for _, name := range path {
+		var found *Command
+		for _, cmd := range container.Commands() {
+			if cmd.Name == name {
+				found = cmd
+				break
+			}
+		}
+
+		if found == nil {
+			break
+		}
+
+		commands = append(commands, found)
+		container = found
+	}
+
+	return commands
+}
+
+// findCommandPath finds the path taken to reach the given command, by name.
+func (a *Application) findCommandPath(cmd *Command) []string {
+	var loop func(container CommandContainer, path []string) []string
+
+	loop = func(container CommandContainer, path []string) []string {
+		for _, sub := range container.Commands() {
+			subPath := append(append([]string{}, path...), sub.Name)
+
+			if sub == cmd {
+				return subPath
+			}
+
+			if found := loop(sub, subPath); found != nil {
+				return found
+			}
+		}
+
+		return nil
+	}
+
+	return loop(a, nil)
+}
+
 // hasHelpOption checks to see if a help flag is set, ignoring values. Uses raw args sent to the
 // application.
 func (a *Application) hasHelpOption(args []string) bool {
@@ -169,6 +382,7 @@ func (a *Application) hasHelpOption(args []string) bool {
 // configure configures pre-defined parameters. This is solely defined for help output.
 func (a *Application) configure(definition *Definition) {
 	var help bool
+	var explainValues bool
 
 	definition.AddOption(OptionDefinition{
 		Value: parameters.NewBoolValue(&help),
@@ -176,9 +390,67 @@ func (a *Application) configure(definition *Definition) {
 		Desc:  "Display contextual help?",
 	})
 
+	if a.ExplainValues {
+		definition.AddOption(OptionDefinition{
+			Value: parameters.NewBoolValue(&explainValues),
+			Spec:  "--" + explainValuesOptionName,
+			Desc:  "Show the value of each option and argument, and where it was set from.",
+		})
+	}
+
+	definition.envPrefix = a.envPrefix(nil)
+
+	if a.Markup {
+		a.configureColor(definition)
+	}
+	a.configureConfig(definition)
+	a.configureOutputFormat(definition)
+
 	for _, opt := range a.globalOptionDefinitions {
 		definition.AddOption(opt)
 	}
+
+	definition.envPrefix = ""
+}
+
+// configureCommand configures the command-level parameters of the given command, reached by the
+// given path. The persistent options of each command along the path are configured first, in order.
+func (a *Application) configureCommand(definition *Definition, cmd *Command, path []string) {
+	if cmd == nil {
+		return
+	}
+
+	definition.inheriting = true
+
+	for i, ancestor := range a.findCommandsOnPath(path) {
+		if ancestor == cmd {
+			break
+		}
+
+		if ancestor.Configure != nil {
+			definition.envPrefix = a.envPrefix(path[:i+1])
+			ancestor.Configure(definition)
+		}
+	}
+
+	definition.inheriting = false
+
+	if cmd.Configure != nil {
+		definition.envPrefix = a.envPrefix(path)
+		cmd.Configure(definition)
+	}
+
+	definition.envPrefix = ""
+}
+
+// envPrefix creates the prefix used to derive environment variable names for options on the
+// command with the given path.
+func (a *Application) envPrefix(path []string) string {
+	if a.EnvPrefix == "" {
+		return ""
+	}
+
+	return envVarName(append([]string{a.EnvPrefix}, path...)...)
 }
 
 // showHelp shows contextual help.
@@ -18,14 +18,15 @@ func DescribeApplication(app *Application) string {
 	help += fmt.Sprintf("%s version %s\n\n", app.Name, app.Version)
 	help += fmt.Sprintf("%s\n", describeApplicationUsage(app))
 
-	options := findApplicationOptions(app)
+	options := visibleOptions(findApplicationOptions(app))
+	commands := visibleCommands(app.commands)
 
 	if len(options) > 0 {
 		help += fmt.Sprintf("\n%s", parameters.DescribeOptions(options))
 	}
 
-	if len(app.commands) > 0 {
-		help += fmt.Sprintf("\n%s", DescribeCommands(app.commands))
+	if len(commands) > 0 {
+		help += fmt.Sprintf("\n%s", DescribeCommands(commands))
 		help += fmt.Sprintf(
 			"\n  Run `$ %s COMMAND --help` for more information about a command.\n",
 			app.UsageName,
@@ -44,11 +45,16 @@ func DescribeApplication(app *Application) string {
 // application has commands and if it does, whether you must run a command for anything to happen.
 func describeApplicationUsage(app *Application) string {
 	desc := "USAGE:\n"
-	desc += fmt.Sprintf("  %s COMMAND [OPTIONS...] [ARGUMENTS...]", app.UsageName)
+	desc += "  " + describeApplicationUsageLine(app)
 
 	return desc
 }
 
+// describeApplicationUsageLine describes how the application is run, on a single line.
+func describeApplicationUsageLine(app *Application) string {
+	return fmt.Sprintf("%s COMMAND [OPTIONS...] [ARGUMENTS...]", app.UsageName)
+}
+
 // findApplicationOptions finds all of the defined options on the given application.
 func findApplicationOptions(app *Application) []parameters.Option {
 	definition := NewDefinition()
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
 	"github.com/seeruk/assert"
 )
 
@@ -115,4 +116,22 @@ func TestDescribeApplication(t *testing.T) {
 
 		assert.False(t, strings.Contains(result, "HELP:"), "Expected no help title.")
 	})
+
+	t.Run("should not show hidden commands or global options", func(t *testing.T) {
+		var value string
+
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+		application.AddCommand(&console.Command{Name: "experiment", Hidden: true})
+		application.AddGlobalOption(console.OptionDefinition{
+			Value:  parameters.NewStringValue(&value),
+			Spec:   "--trace-id=ID",
+			Hidden: true,
+		})
+
+		result := console.DescribeApplication(application)
+
+		assert.False(t, strings.Contains(result, "experiment"), "Expected no hidden command.")
+		assert.False(t, strings.Contains(result, "COMMANDS:"), "Expected no commands title.")
+		assert.False(t, strings.Contains(result, "--trace-id"), "Expected no hidden option.")
+	})
 }
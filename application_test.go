@@ -2,12 +2,15 @@ package console_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"math/rand"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/eidolon/console"
 	"github.com/eidolon/console/parameters"
@@ -59,11 +62,64 @@ func TestApplication(t *testing.T) {
 			assert.Equal(t, 100, code)
 		})
 
-		t.Run("should return exit code 2 if no command was found", func(t *testing.T) {
+		t.Run("should return exit code 102 if no command was found", func(t *testing.T) {
 			writer := bytes.Buffer{}
+			errWriter := bytes.Buffer{}
 			application := createApplication(&writer)
+			application.ErrorWriter = &errWriter
 			code := application.Run([]string{"foo"}, []string{})
 
+			assert.Equal(t, 102, code)
+			assert.True(t, strings.Contains(errWriter.String(), "Unknown command 'foo'"), "Expected error.")
+			assert.False(t, strings.Contains(writer.String(), "USAGE:"), "Expected no help.")
+		})
+
+		t.Run("should suggest similarly named commands and aliases", func(t *testing.T) {
+			var a string
+			var b int
+
+			writer := bytes.Buffer{}
+			errWriter := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.ErrorWriter = &errWriter
+			application.AddCommand(createTestCommand(&a, &b))
+			code := application.Run([]string{"tset"}, []string{})
+
+			assert.Equal(t, 102, code)
+			assert.True(t, strings.Contains(errWriter.String(), "did you mean 'test'?"), "Expected suggestions.")
+		})
+
+		t.Run("should report unknown sub-commands at the right depth", func(t *testing.T) {
+			var a string
+			var b int
+
+			parent := &console.Command{Name: "parent"}
+			parent.AddCommand(createTestCommand(&a, &b))
+
+			writer := bytes.Buffer{}
+			errWriter := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.ErrorWriter = &errWriter
+			application.AddCommand(parent)
+			code := application.Run([]string{"parent", "tets"}, []string{})
+
+			assert.Equal(t, 102, code)
+			assert.True(t, strings.Contains(errWriter.String(), "Unknown command 'parent tets', did you mean 'test'?"), "Expected error.")
+			assert.True(t, strings.Contains(errWriter.String(), "parent --help"), "Expected help hint.")
+		})
+
+		t.Run("should show help if a command that can't be executed is given alone", func(t *testing.T) {
+			var a string
+			var b int
+
+			parent := &console.Command{Name: "parent"}
+			parent.AddCommand(createTestCommand(&a, &b))
+
+			writer := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.AddCommand(parent)
+			code := application.Run([]string{"parent"}, []string{})
+
 			assert.Equal(t, 100, code)
 		})
 
@@ -146,6 +202,289 @@ func TestApplication(t *testing.T) {
 			assert.Equal(t, 101, code)
 		})
 
+		t.Run("should return exit code 101 if validating input fails", func(t *testing.T) {
+			var executed bool
+
+			writer := bytes.Buffer{}
+			errWriter := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.ErrorWriter = &errWriter
+			application.AddCommand(&console.Command{
+				Name: "test",
+				Validate: func(input *console.Input) error {
+					return errors.New("Testing validation errors")
+				},
+				Execute: func(input *console.Input, output *console.Output) error {
+					executed = true
+					return nil
+				},
+			})
+
+			code := application.Run([]string{"test"}, []string{})
+
+			assert.Equal(t, 101, code)
+			assert.False(t, executed, "Expected command not to be executed.")
+			assert.True(t, strings.Contains(errWriter.String(), "Testing validation errors"), "Expected error.")
+		})
+
+		t.Run("should explain values instead of executing if the explain values flag is set", func(t *testing.T) {
+			var a string
+			var b int
+
+			writer := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.ExplainValues = true
+			application.AddCommand(createTestCommand(&a, &b))
+
+			code := application.Run([]string{"test", "aval", "--explain-values"}, []string{})
+
+			assert.Equal(t, 0, code)
+			assert.False(t, strings.Contains(writer.String(), "STRINGARG = "), "Expected no execution.")
+			assert.True(t, strings.Contains(writer.String(), "(input: argument 1)"), "Expected origin.")
+		})
+
+		t.Run("should not add the explain values option unless it's enabled", func(t *testing.T) {
+			var a string
+			var b int
+
+			writer := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.AddCommand(createTestCommand(&a, &b))
+			application.AddGlobalOption(console.OptionDefinition{
+				Value: parameters.NewBoolValue(new(bool)),
+				Spec:  "--explain-values",
+			})
+
+			code := application.Run([]string{"test", "aval", "--explain-values"}, []string{})
+
+			assert.Equal(t, 0, code)
+			assert.True(t, strings.Contains(writer.String(), "STRINGARG = "), "Expected execution.")
+		})
+
+		t.Run("should ignore unknown input unless strict mode is enabled", func(t *testing.T) {
+			var a string
+			var b int
+
+			writer := bytes.Buffer{}
+			errWriter := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.AddCommand(createTestCommand(&a, &b))
+
+			code := application.Run([]string{"test", "aval", "extra", "--int-otp=1"}, []string{})
+			assert.Equal(t, 0, code)
+
+			application = createApplication(&writer)
+			application.AddCommand(createTestCommand(&a, &b))
+			application.ErrorWriter = &errWriter
+			application.Strict = true
+
+			code = application.Run([]string{"test", "aval", "extra", "--int-otp=1"}, []string{})
+			assert.Equal(t, 101, code)
+			assert.True(t, strings.Contains(errWriter.String(), "did you mean '--int-opt'?"), "Expected suggestion.")
+			assert.True(t, strings.Contains(errWriter.String(), "unexpected 'extra'"), "Expected surplus argument.")
+		})
+
+		t.Run("should enable strict mode for individual commands", func(t *testing.T) {
+			var a string
+			var b int
+
+			command := createTestCommand(&a, &b)
+			command.Strict = true
+
+			writer := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.AddCommand(command)
+
+			code := application.Run([]string{"test", "aval", "--unknown"}, []string{})
+			assert.Equal(t, 101, code)
+		})
+
+		t.Run("should run hidden commands", func(t *testing.T) {
+			writer := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.AddCommand(&console.Command{
+				Name:   "experiment",
+				Hidden: true,
+				Execute: func(input *console.Input, output *console.Output) error {
+					output.Print("ran")
+					return nil
+				},
+			})
+
+			code := application.Run([]string{"experiment"}, []string{})
+			assert.Equal(t, 0, code)
+			assert.Equal(t, "ran", writer.String())
+		})
+
+		t.Run("should not suggest hidden commands", func(t *testing.T) {
+			writer := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.AddCommand(&console.Command{Name: "experiment", Hidden: true})
+
+			code := application.Run([]string{"experimant"}, []string{})
+			assert.Equal(t, 102, code)
+			assert.False(t, strings.Contains(writer.String(), "did you mean"), "Expected no suggestions.")
+		})
+
+		t.Run("should warn when deprecated commands, arguments, and options are used", func(t *testing.T) {
+			var name string
+			var user string
+
+			command := &console.Command{
+				Name:       "ls",
+				Deprecated: "use 'nodes list' instead",
+				Configure: func(definition *console.Definition) {
+					definition.AddArgument(console.ArgumentDefinition{
+						Value:      parameters.NewStringValue(&user),
+						Spec:       "[USER]",
+						Deprecated: "use --name instead",
+					})
+
+					definition.AddOption(console.OptionDefinition{
+						Value:      parameters.NewStringValue(&name),
+						Spec:       "--user=NAME",
+						Deprecated: "use --name instead",
+					})
+				},
+				Execute: func(input *console.Input, output *console.Output) error {
+					output.Println("ran")
+					return nil
+				},
+			}
+
+			writer := bytes.Buffer{}
+			errWriter := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.ErrorWriter = &errWriter
+			application.AddCommand(command)
+
+			code := application.Run([]string{"ls", "--user=foo", "bar"}, []string{})
+			assert.Equal(t, 0, code)
+
+			expected := "Warning: The command 'ls' is deprecated, use 'nodes list' instead\n" +
+				"Warning: The argument 'USER' is deprecated, use --name instead\n" +
+				"Warning: The option '--user' is deprecated, use --name instead\n"
+
+			assert.Equal(t, expected, errWriter.String())
+			assert.Equal(t, "ran\n", writer.String())
+		})
+
+		t.Run("should not warn about deprecated arguments and options that aren't used", func(t *testing.T) {
+			var name string
+
+			writer := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.AddCommand(&console.Command{
+				Name: "test",
+				Configure: func(definition *console.Definition) {
+					definition.AddOption(console.OptionDefinition{
+						Value:      parameters.NewStringValue(&name),
+						Spec:       "--user=NAME",
+						Default:    "foo",
+						Deprecated: "use --name instead",
+					})
+				},
+				Execute: func(input *console.Input, output *console.Output) error {
+					return nil
+				},
+			})
+
+			code := application.Run([]string{"test"}, []string{})
+			assert.Equal(t, 0, code)
+			assert.Equal(t, "", writer.String())
+		})
+
+		t.Run("should pass deprecation warnings to OnDeprecated if it is set", func(t *testing.T) {
+			var warnings []console.DeprecationWarning
+
+			writer := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.OnDeprecated = func(output *console.Output, warning console.DeprecationWarning) {
+				warnings = append(warnings, warning)
+			}
+
+			parent := &console.Command{Name: "node", Deprecated: "use 'nodes' instead"}
+			parent.AddCommand(&console.Command{
+				Name: "ls",
+				Execute: func(input *console.Input, output *console.Output) error {
+					return nil
+				},
+			})
+
+			application.AddCommand(parent)
+
+			code := application.Run([]string{"node", "ls"}, []string{})
+			assert.Equal(t, 0, code)
+			assert.Equal(t, "", writer.String())
+			assert.Equal(t, []console.DeprecationWarning{
+				{Kind: "command", Name: "node", Message: "use 'nodes' instead"},
+			}, warnings)
+		})
+
+		t.Run("should run context-aware commands with the given context", func(t *testing.T) {
+			type contextKey struct{}
+
+			writer := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.AddCommand(&console.Command{
+				Name: "test",
+				ExecuteContext: func(ctx context.Context, input *console.Input, output *console.Output) error {
+					output.Print(ctx.Value(contextKey{}))
+					return nil
+				},
+			})
+
+			ctx := context.WithValue(context.Background(), contextKey{}, "value")
+
+			code := application.RunContext(ctx, []string{"test"}, []string{})
+			assert.Equal(t, 0, code)
+			assert.Equal(t, "value", writer.String())
+		})
+
+		t.Run("should cancel the context when the command times out", func(t *testing.T) {
+			writer := bytes.Buffer{}
+			errWriter := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.ErrorWriter = &errWriter
+			application.AddCommand(&console.Command{
+				Name:    "test",
+				Timeout: 10 * time.Millisecond,
+				ExecuteContext: func(ctx context.Context, input *console.Input, output *console.Output) error {
+					<-ctx.Done()
+					return ctx.Err()
+				},
+			})
+
+			code := application.Run([]string{"test"}, []string{})
+			assert.Equal(t, 1, code)
+			assert.True(t, strings.Contains(errWriter.String(), context.DeadlineExceeded.Error()), "Expected timeout.")
+		})
+
+		t.Run("should cancel the context and exit with 130 when interrupted", func(t *testing.T) {
+			writer := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.AddCommand(&console.Command{
+				Name: "test",
+				ExecuteContext: func(ctx context.Context, input *console.Input, output *console.Output) error {
+					process, err := os.FindProcess(os.Getpid())
+					if err != nil {
+						return err
+					}
+
+					if err := process.Signal(os.Interrupt); err != nil {
+						return err
+					}
+
+					<-ctx.Done()
+					return ctx.Err()
+				},
+			})
+
+			code := application.Run([]string{"test"}, []string{})
+			assert.Equal(t, 130, code)
+			assert.Equal(t, "", writer.String())
+		})
+
 		t.Run("should return exit code 1 if the command execution fails", func(t *testing.T) {
 			writer := bytes.Buffer{}
 			application := createApplication(&writer)
@@ -218,6 +557,111 @@ func TestApplication(t *testing.T) {
 			//assert.Equal(t, "bar", foo)
 		})
 
+		t.Run("should derive env var names from the env prefix", func(t *testing.T) {
+			var global string
+			var name string
+			var token string
+
+			command := &console.Command{
+				Name: "greet",
+				Configure: func(definition *console.Definition) {
+					definition.AddOption(console.OptionDefinition{
+						Value: parameters.NewStringValue(&name),
+						Spec:  "-n, --name=NAME",
+					})
+
+					definition.AddOption(console.OptionDefinition{
+						Value:  parameters.NewStringValue(&token),
+						Spec:   "--api-token=TOKEN",
+						EnvVar: "TOKEN",
+					})
+				},
+				Execute: func(input *console.Input, output *console.Output) error {
+					return nil
+				},
+			}
+
+			parent := &console.Command{Name: "hello"}
+			parent.AddCommand(command)
+
+			writer := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.EnvPrefix = "myapp"
+			application.AddGlobalOption(console.OptionDefinition{
+				Value: parameters.NewStringValue(&global),
+				Spec:  "--global-opt=VALUE",
+			})
+
+			application.AddCommand(parent)
+
+			code := application.Run([]string{"hello", "greet"}, []string{
+				"MYAPP_GLOBAL_OPT=foo",
+				"MYAPP_HELLO_GREET_NAME=bar",
+				"MYAPP_HELLO_GREET_API_TOKEN=baz",
+				"TOKEN=qux",
+			})
+
+			assert.Equal(t, 0, code)
+			assert.Equal(t, "foo", global)
+			assert.Equal(t, "bar", name)
+			assert.Equal(t, "qux", token)
+		})
+
+		t.Run("should inherit persistent options from parent commands", func(t *testing.T) {
+			var clusterContext string
+			var verbose bool
+			var name string
+
+			parent := &console.Command{
+				Name: "cluster",
+				Configure: func(definition *console.Definition) {
+					definition.AddOption(console.OptionDefinition{
+						Value:      parameters.NewStringValue(&clusterContext),
+						Spec:       "--context=CONTEXT",
+						Persistent: true,
+					})
+
+					definition.AddOption(console.OptionDefinition{
+						Value: parameters.NewBoolValue(&verbose),
+						Spec:  "--verbose",
+					})
+				},
+			}
+
+			parent.AddCommand(&console.Command{
+				Name: "nodes",
+				Configure: func(definition *console.Definition) {
+					definition.AddArgument(console.ArgumentDefinition{
+						Value: parameters.NewStringValue(&name),
+						Spec:  "NAME",
+					})
+				},
+				Execute: func(input *console.Input, output *console.Output) error {
+					return nil
+				},
+			})
+
+			writer := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.EnvPrefix = "myapp"
+			application.Strict = true
+			application.AddCommand(parent)
+
+			code := application.Run([]string{"cluster", "nodes", "foo"}, []string{"MYAPP_CLUSTER_CONTEXT=prod"})
+			assert.Equal(t, 0, code)
+			assert.Equal(t, "prod", clusterContext)
+			assert.Equal(t, "foo", name)
+
+			writer.Reset()
+			application = createApplication(&writer)
+			application.Strict = true
+			application.AddCommand(parent)
+
+			code = application.Run([]string{"cluster", "nodes", "--verbose", "foo"}, []string{})
+			assert.Equal(t, 101, code)
+			assert.False(t, verbose, "Expected non-persistent option to be unknown.")
+		})
+
 		t.Run("should work with sub-commands", func(t *testing.T) {
 			message := fmt.Sprintf("sub-command: %d", rand.Int())
 
@@ -0,0 +1,181 @@
+package console
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eidolon/console/parameters"
+)
+
+// Bind adds an argument or option to the Definition for each field of the given struct that has a
+// `console` tag, holding it's specification. Specifications that start with a hyphen are options,
+// and anything else is an argument. The value used for each field is picked by it's type, unless
+// the field, or it's address, is already a parameters.Value. Other tags configure the rest of the
+// definition:
+//
+//   - `desc`: the description.
+//   - `default`: the default value.
+//   - `env`: the name of an environment variable to read an option value from.
+//   - `enum`: comma-separated choices, for string fields.
+//   - `required`, `hidden`, `persistent`: "true" to enable.
+//   - `deprecated`: what to use instead.
+//
+// Embedded structs without a `console` tag are bound too. Anything other than a pointer to a
+// struct, and fields of unsupported types, will result in an error.
+func (d *Definition) Bind(target interface{}) {
+	value := reflect.ValueOf(target)
+
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		panic(fmt.Errorf("console: Cannot bind to %T, expected a pointer to a struct", target))
+	}
+
+	d.bindStruct(value.Elem())
+}
+
+// bindStruct adds an argument or option to the Definition for each tagged field of the given
+// struct value.
+func (d *Definition) bindStruct(value reflect.Value) {
+	structType := value.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		spec, ok := field.Tag.Lookup("console")
+		if !ok {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				d.bindStruct(value.Field(i))
+			}
+
+			continue
+		}
+
+		if !field.IsExported() {
+			panic(fmt.Errorf("console: Cannot bind unexported field '%s'", field.Name))
+		}
+
+		fieldValue := bindValue(field, value.Field(i).Addr())
+
+		if strings.HasPrefix(strings.TrimSpace(spec), "-") {
+			d.AddOption(OptionDefinition{
+				Value:      fieldValue,
+				Spec:       spec,
+				Desc:       field.Tag.Get("desc"),
+				EnvVar:     field.Tag.Get("env"),
+				Required:   boolTag(field, "required"),
+				Default:    field.Tag.Get("default"),
+				Hidden:     boolTag(field, "hidden"),
+				Deprecated: field.Tag.Get("deprecated"),
+				Persistent: boolTag(field, "persistent"),
+			})
+
+			continue
+		}
+
+		d.AddArgument(ArgumentDefinition{
+			Value:      fieldValue,
+			Spec:       spec,
+			Desc:       field.Tag.Get("desc"),
+			Default:    field.Tag.Get("default"),
+			Hidden:     boolTag(field, "hidden"),
+			Deprecated: field.Tag.Get("deprecated"),
+		})
+	}
+}
+
+// bindValue picks the parameters.Value to use for a struct field, given a pointer to it.
+func bindValue(field reflect.StructField, ptr reflect.Value) parameters.Value {
+	if value, ok := ptr.Interface().(parameters.Value); ok {
+		return value
+	}
+
+	if value, ok := ptr.Elem().Interface().(parameters.Value); ok && !ptr.Elem().IsZero() {
+		return value
+	}
+
+	if choices, ok := field.Tag.Lookup("enum"); ok {
+		if ref, ok := ptr.Interface().(*string); ok {
+			return parameters.NewEnumValue(ref, strings.Split(choices, ",")...)
+		}
+
+		panic(fmt.Errorf("console: Cannot bind field '%s' with choices, expected a string", field.Name))
+	}
+
+	switch ref := ptr.Interface().(type) {
+	case *bool:
+		return parameters.NewBoolValue(ref)
+	case *time.Time:
+		return parameters.NewDateValue(ref)
+	case *time.Duration:
+		return parameters.NewDurationValue(ref)
+	case *float32:
+		return parameters.NewFloat32Value(ref)
+	case *float64:
+		return parameters.NewFloat64Value(ref)
+	case *int:
+		return parameters.NewIntValue(ref)
+	case *net.IP:
+		return parameters.NewIPValue(ref)
+	case *string:
+		return parameters.NewStringValue(ref)
+	case *url.URL:
+		return parameters.NewURLValue(ref)
+	case *[]time.Duration:
+		return parameters.NewDurationSliceValue(ref)
+	case *[]int:
+		return parameters.NewIntSliceValue(ref)
+	case *map[string]string:
+		return parameters.NewStringMapValue(ref)
+	case *[]string:
+		return parameters.NewStringSliceValue(ref)
+	}
+
+	panic(fmt.Errorf("console: Cannot bind field '%s' of unsupported type %s", field.Name, field.Type))
+}
+
+// boolTag reads a boolean tag from a struct field, false if it isn't set.
+func boolTag(field reflect.StructField, name string) bool {
+	tag := field.Tag.Get(name)
+	if tag == "" {
+		return false
+	}
+
+	value, err := strconv.ParseBool(tag)
+	if err != nil {
+		panic(fmt.Errorf("console: Invalid '%s' tag on field '%s': '%w'", name, field.Name, err))
+	}
+
+	return value
+}
+
+// ExecuteWith points a command at a struct of type T, whose tagged fields are bound as the
+// command's arguments and options (see Definition.Bind), and sets the command to execute the given
+// function with the populated struct. Any existing Configure function is still called, before the
+// struct is bound.
+func ExecuteWith[T any](cmd *Command, execute func(ctx context.Context, params *T, input *Input, output *Output) error) *Command {
+	params := new(T)
+	configure := cmd.Configure
+
+	cmd.Configure = func(definition *Definition) {
+		if configure != nil {
+			configure(definition)
+		}
+
+		// Start from a clean struct each time, as a command may be configured more than once.
+		var zero T
+		*params = zero
+
+		definition.Bind(params)
+	}
+
+	cmd.ExecuteContext = func(ctx context.Context, input *Input, output *Output) error {
+		return execute(ctx, params, input, output)
+	}
+
+	return cmd
+}
@@ -0,0 +1,158 @@
+package console_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+	"github.com/seeruk/assert"
+)
+
+type greetParams struct {
+	Name    string        `console:"-n, --name=NAME" env:"EXAMPLE_NAME" desc:"The name to greet." default:"World"`
+	Format  string        `console:"--format=FORMAT" enum:"text,json" default:"text"`
+	Timeout time.Duration `console:"--timeout=TIMEOUT" hidden:"true"`
+	Tags    []string      `console:"--tag=TAG"`
+	Target  string        `console:"[TARGET]" desc:"Who to greet."`
+
+	loggingParams
+
+	ignored string
+}
+
+type loggingParams struct {
+	Verbose bool `console:"-v, --verbose" persistent:"true"`
+}
+
+func TestDefinitionBind(t *testing.T) {
+	t.Run("should add options and arguments for tagged fields", func(t *testing.T) {
+		var params greetParams
+
+		definition := console.NewDefinition()
+		definition.Bind(&params)
+
+		options := definition.Options()
+		assert.Equal(t, 5, len(options))
+		assert.Equal(t, []string{"n", "name"}, options[0].Names)
+		assert.Equal(t, "The name to greet.", options[0].Description)
+		assert.Equal(t, []string{"EXAMPLE_NAME"}, options[0].EnvVars)
+		assert.Equal(t, "World", options[0].Default)
+		assert.True(t, options[2].Hidden, "Expected hidden option.")
+
+		arguments := definition.Arguments()
+		assert.Equal(t, 1, len(arguments))
+		assert.Equal(t, "TARGET", arguments[0].Name)
+		assert.Equal(t, "Who to greet.", arguments[0].Description)
+	})
+
+	t.Run("should map input into the struct", func(t *testing.T) {
+		var params greetParams
+
+		definition := console.NewDefinition()
+		definition.Bind(&params)
+
+		input := console.ParseInput2(definition, []string{"--format=json", "--tag", "a", "--tag", "b", "-v", "Alice"})
+
+		err := console.MapInput2(definition, input, []string{"EXAMPLE_NAME=Bob"})
+		assert.OK(t, err)
+		assert.Equal(t, "Bob", params.Name)
+		assert.Equal(t, "json", params.Format)
+		assert.Equal(t, []string{"a", "b"}, params.Tags)
+		assert.Equal(t, "Alice", params.Target)
+		assert.True(t, params.Verbose, "Expected embedded field to be bound.")
+	})
+
+	t.Run("should use fields that are already values", func(t *testing.T) {
+		var name string
+
+		params := struct {
+			Name *parameters.StringValue `console:"--name=NAME"`
+		}{
+			Name: parameters.NewStringValue(&name),
+		}
+
+		definition := console.NewDefinition()
+		definition.Bind(&params)
+
+		input := console.ParseInput2(definition, []string{"--name=Alice"})
+
+		err := console.MapInput2(definition, input, []string{})
+		assert.OK(t, err)
+		assert.Equal(t, "Alice", name)
+	})
+
+	t.Run("should reject invalid choices", func(t *testing.T) {
+		var params greetParams
+
+		definition := console.NewDefinition()
+		definition.Bind(&params)
+
+		input := console.ParseInput2(definition, []string{"--format=yaml"})
+
+		err := console.MapInput2(definition, input, []string{})
+		assert.NotOK(t, err)
+	})
+
+	t.Run("should panic if not given a pointer to a struct", func(t *testing.T) {
+		defer func() {
+			assert.True(t, recover() != nil, "Expected a panic.")
+		}()
+
+		console.NewDefinition().Bind(greetParams{})
+	})
+
+	t.Run("should panic for fields of unsupported types", func(t *testing.T) {
+		defer func() {
+			assert.True(t, recover() != nil, "Expected a panic.")
+		}()
+
+		params := struct {
+			Channel chan int `console:"--channel=CHANNEL"`
+		}{}
+
+		console.NewDefinition().Bind(&params)
+	})
+}
+
+func TestExecuteWith(t *testing.T) {
+	t.Run("should execute with the populated struct", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+		application.Writer = &writer
+		application.AddCommand(console.ExecuteWith(&console.Command{Name: "greet"},
+			func(ctx context.Context, params *greetParams, input *console.Input, output *console.Output) error {
+				output.Printf("Hello, %s!", params.Name)
+				return nil
+			},
+		))
+
+		code := application.Run([]string{"greet", "--name", "Alice"}, []string{})
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "Hello, Alice!", writer.String())
+	})
+
+	t.Run("should still call an existing configure function", func(t *testing.T) {
+		var extra string
+
+		cmd := console.ExecuteWith(&console.Command{
+			Name: "greet",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringValue(&extra),
+					Spec:  "--extra=EXTRA",
+				})
+			},
+		}, func(ctx context.Context, params *greetParams, input *console.Input, output *console.Output) error {
+			return nil
+		})
+
+		definition := console.NewDefinition()
+		cmd.Configure(definition)
+
+		assert.Equal(t, 6, len(definition.Options()))
+	})
+}
@@ -0,0 +1,106 @@
+package console
+
+import (
+	"io"
+	"os"
+
+	"github.com/eidolon/console/parameters"
+	"github.com/eidolon/console/style"
+)
+
+// colorOptionName is the name of the global option used to choose when to use colors and styles.
+const colorOptionName = "color"
+
+// ColorMode decides whether or not markup in output is rendered as colors and styles.
+type ColorMode string
+
+const (
+	// ColorAuto renders styles only if the writer is a terminal, unless the NO_COLOR or FORCE_COLOR
+	// environment variables are set.
+	ColorAuto ColorMode = "auto"
+	// ColorAlways renders styles, even if the writer isn't a terminal.
+	ColorAlways ColorMode = "always"
+	// ColorNever strips styles.
+	ColorNever ColorMode = "never"
+)
+
+// AddStyle makes a style available to markup in output, by the given name, e.g. `<warning>` for a
+// style named "warning". Built-in styles may be replaced. See the style package for the markup
+// that is supported.
+func (a *Application) AddStyle(name string, s style.Style) {
+	if a.styles == nil {
+		a.styles = style.NewRenderer()
+	}
+
+	a.styles.AddStyle(name, s)
+}
+
+// configureColor adds the global option used to choose when to use colors and styles.
+func (a *Application) configureColor(definition *Definition) {
+	var colorMode string
+
+	definition.AddOption(OptionDefinition{
+		Value: parameters.NewEnumValue(&colorMode, string(ColorAuto), string(ColorAlways), string(ColorNever)),
+		Spec:  "--" + colorOptionName + "=WHEN",
+		Desc:  "When to use colors and styles, one of 'auto', 'always', 'never'.",
+	})
+}
+
+// applyColor passes the color mode chosen with the global option, in the input, the environment,
+// or a config file, on to the output.
+func (a *Application) applyColor() {
+	if mode := a.definition.optionValue(colorOptionName); mode != "" {
+		a.output.Color = ColorMode(mode)
+	}
+}
+
+// isColored checks whether or not markup written to the given writer should be rendered as colors
+// and styles. In ColorAuto mode, NO_COLOR disables styles, FORCE_COLOR enables them, and
+// otherwise they're enabled if the writer is a terminal.
+func (o *Output) isColored(w io.Writer) bool {
+	switch o.Color {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	if o.getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	if force := o.getenv("FORCE_COLOR"); force != "" && force != "0" && force != "false" {
+		return true
+	}
+
+	if o.getenv("TERM") == "dumb" {
+		return false
+	}
+
+	return isTerminal(w)
+}
+
+// getenv gets the value of an environment variable, from the environment the application was run
+// with, if there is one.
+func (o *Output) getenv(name string) string {
+	if o.env == nil {
+		return os.Getenv(name)
+	}
+
+	return o.env[name]
+}
+
+// isTerminal checks whether the given writer is a terminal, i.e. a file that is a character device.
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,154 @@
+package console_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+	"github.com/eidolon/console/style"
+	"github.com/seeruk/assert"
+)
+
+func TestApplicationColor(t *testing.T) {
+	createApplication := func(writer *bytes.Buffer, errWriter *bytes.Buffer) *console.Application {
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+		application.Writer = writer
+		application.ErrorWriter = errWriter
+		application.Markup = true
+		application.AddCommand(&console.Command{
+			Name:        "status",
+			Description: "Show <info>status</info>.",
+			Execute: func(input *console.Input, output *console.Output) error {
+				output.Print("<info>ok</info>")
+				return nil
+			},
+		})
+
+		return application
+	}
+
+	t.Run("should strip styles if the writer isn't a terminal", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		code := createApplication(&writer, &bytes.Buffer{}).Run([]string{"status"}, []string{})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "ok", writer.String())
+	})
+
+	t.Run("should render styles if --color=always is given", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		code := createApplication(&writer, &bytes.Buffer{}).Run([]string{"status", "--color=always"}, []string{})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "\x1b[32mok\x1b[0m", writer.String())
+	})
+
+	t.Run("should render styles if FORCE_COLOR is set", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		code := createApplication(&writer, &bytes.Buffer{}).Run([]string{"status"}, []string{"FORCE_COLOR=1"})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "\x1b[32mok\x1b[0m", writer.String())
+	})
+
+	t.Run("should strip styles if NO_COLOR is set, unless --color=always is given", func(t *testing.T) {
+		env := []string{"NO_COLOR=1", "FORCE_COLOR=1"}
+
+		writer := bytes.Buffer{}
+		createApplication(&writer, &bytes.Buffer{}).Run([]string{"status"}, env)
+		assert.Equal(t, "ok", writer.String())
+
+		writer = bytes.Buffer{}
+		createApplication(&writer, &bytes.Buffer{}).Run([]string{"status", "--color", "always"}, env)
+		assert.Equal(t, "\x1b[32mok\x1b[0m", writer.String())
+	})
+
+	t.Run("should strip styles if --color=never is given", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		createApplication(&writer, &bytes.Buffer{}).Run([]string{"status", "--color=never"}, []string{"FORCE_COLOR=1"})
+		assert.Equal(t, "ok", writer.String())
+	})
+
+	t.Run("should use the chosen colors in help", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		code := createApplication(&writer, &bytes.Buffer{}).Run([]string{"--color=always"}, []string{})
+
+		assert.Equal(t, 100, code)
+		assert.True(t, strings.Contains(writer.String(), "Show \x1b[32mstatus\x1b[0m."), "Expected styled description.")
+	})
+
+	t.Run("should render added styles", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		application := createApplication(&writer, &bytes.Buffer{})
+		application.AddStyle("info", style.Style{Foreground: "blue"})
+
+		application.Run([]string{"status", "--color=always"}, []string{})
+		assert.Equal(t, "\x1b[34mok\x1b[0m", writer.String())
+	})
+
+	t.Run("should write errors as they are", func(t *testing.T) {
+		errWriter := bytes.Buffer{}
+
+		application := createApplication(&bytes.Buffer{}, &errWriter)
+		application.AddCommand(&console.Command{
+			Name: "fail",
+			Execute: func(input *console.Input, output *console.Output) error {
+				return errors.New("expected <b>")
+			},
+		})
+
+		code := application.Run([]string{"fail", "--color=always"}, []string{})
+
+		assert.Equal(t, 1, code)
+		assert.True(t, strings.HasPrefix(errWriter.String(), "expected <b>\n"), "Expected unstyled error.")
+	})
+
+	t.Run("should write markup as it is unless markup is enabled", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		application := createApplication(&writer, &bytes.Buffer{})
+		application.Markup = false
+
+		code := application.Run([]string{"status"}, []string{"FORCE_COLOR=1"})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "<info>ok</info>", writer.String())
+	})
+
+	t.Run("should not add the color option unless markup is enabled", func(t *testing.T) {
+		var color string
+
+		writer := bytes.Buffer{}
+
+		application := createApplication(&writer, &bytes.Buffer{})
+		application.Markup = false
+		application.AddGlobalOption(console.OptionDefinition{
+			Value: parameters.NewStringValue(&color),
+			Spec:  "--color=COLOR",
+		})
+
+		code := application.Run([]string{"status", "--color=blue"}, []string{})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "blue", color)
+		assert.Equal(t, "<info>ok</info>", writer.String())
+	})
+
+	t.Run("should exit with code 101 if the color mode is unknown", func(t *testing.T) {
+		errWriter := bytes.Buffer{}
+
+		code := createApplication(&bytes.Buffer{}, &errWriter).Run([]string{"status", "--color=sometimes"}, []string{})
+
+		assert.Equal(t, 101, code)
+		assert.True(t, strings.Contains(errWriter.String(), "'auto', 'always', 'never'"), "Expected choices.")
+	})
+}
@@ -1,5 +1,10 @@
 package console
 
+import (
+	"context"
+	"time"
+)
+
 // CommandContainer is the interface that provides a method to get commands on an object.
 type CommandContainer interface {
 	// Commands gets commands from an object.
@@ -9,9 +14,39 @@ type CommandContainer interface {
 // ConfigureFunc is a function to mutate the input definition to add arguments and options.
 type ConfigureFunc func(*Definition)
 
+// ValidateFunc is a function to check the mapped input as a whole, for rules that can't be expressed
+// as option constraints.
+type ValidateFunc func(input *Input) error
+
 // ExecuteFunc is a function to perform whatever task this command does.
 type ExecuteFunc func(input *Input, output *Output) error
 
+// ExecuteContextFunc is a function to perform whatever task this command does, given a context that
+// is cancelled when the application is interrupted, or the command times out.
+type ExecuteContextFunc func(ctx context.Context, input *Input, output *Output) error
+
+// PreRunFunc is a function to run before a command is executed, e.g. to open a client connection.
+// Returning an error stops the command from being executed.
+type PreRunFunc func(ctx context.Context, input *Input, output *Output) error
+
+// PostRunFunc is a function to run after a command is executed, given the error the command, or any
+// hooks run after it, returned. The returned error replaces the given one, so hooks that don't
+// handle the error should return it as-is.
+type PostRunFunc func(ctx context.Context, input *Input, output *Output, err error) error
+
+// Middleware is a function that wraps the execution of every command in an application, e.g. to time
+// or log execution. It should call next to carry on executing the command.
+type Middleware func(next ExecuteContextFunc) ExecuteContextFunc
+
+// CommandGroup represents a titled group of commands, that are shown together in help output.
+type CommandGroup struct {
+	// The title of the group, e.g. "Cluster management".
+	Title string
+	// The position of the group in help output, lowest first. Groups with the same order are sorted
+	// by their title.
+	Order int
+}
+
 // Command represents a command to run in an application.
 type Command struct {
 	// The name of the command.
@@ -22,10 +57,36 @@ type Command struct {
 	Description string
 	// Help message for the command.
 	Help string
+	// The group the command is shown in, in help output. Ungrouped commands are shown first.
+	Group *CommandGroup
+	// Whether or not the command is left out of help output, suggestions, and completion. Hidden
+	// commands can still be run, e.g. for experimental features.
+	Hidden bool
+	// If set, the command is deprecated, and this describes what to use instead, e.g. "use 'nodes
+	// list' instead". A warning is shown when a deprecated command is run.
+	Deprecated string
+	// Whether or not unknown options, and more arguments than are defined, are errors when this
+	// command is run. Strict mode may also be enabled for every command on the Application.
+	Strict bool
 	// Function to configure command-level parameters.
 	Configure ConfigureFunc
+	// Function to validate input after it has been mapped, before executing.
+	Validate ValidateFunc
 	// Function to execute when this command is requested.
 	Execute ExecuteFunc
+	// Function to execute when this command is requested, with a context that is cancelled when the
+	// application receives SIGINT or SIGTERM, or the timeout passes. Used instead of Execute if set.
+	ExecuteContext ExecuteContextFunc
+	// How long the command may run for before it's context is cancelled. No limit if zero.
+	Timeout time.Duration
+	// Function to run before this command is executed.
+	PreRun PreRunFunc
+	// Function to run after this command is executed.
+	PostRun PostRunFunc
+	// Function to run before this command, or any of it's descendants, is executed.
+	PersistentPreRun PreRunFunc
+	// Function to run after this command, or any of it's descendants, is executed.
+	PersistentPostRun PostRunFunc
 
 	// Array of sub-commands. May contain sub-commands.
 	commands []*Command
@@ -49,3 +110,17 @@ func (c *Command) AddCommand(command *Command) *Command {
 func (c *Command) Commands() []*Command {
 	return c.commands
 }
+
+// executable reports whether the command has a function to execute.
+func (c *Command) executable() bool {
+	return c.Execute != nil || c.ExecuteContext != nil
+}
+
+// execute runs the command's execute function, whichever kind it has.
+func (c *Command) execute(ctx context.Context, input *Input, output *Output) error {
+	if c.ExecuteContext != nil {
+		return c.ExecuteContext(ctx, input, output)
+	}
+
+	return c.Execute(input, output)
+}
@@ -13,21 +13,43 @@ import (
 func DescribeCommand(app *Application, cmd *Command, path []string) string {
 	var help string
 
-	arguments := findCommandArguments(app, cmd)
-	options := findCommandOptions(app, cmd)
+	definition := buildCommandDefinition(app, cmd)
+	arguments := visibleArguments(definition.Arguments())
+	options := visibleOptions(definition.Options())
+	commands := visibleCommands(cmd.commands)
+
+	ownOptions, inheritedOptions := splitInheritedOptions(options)
+
+	var ownConstraints, inheritedConstraints []parameters.OptionConstraint
+	for _, constraint := range definition.Constraints() {
+		if isInheritedConstraint(definition, constraint) {
+			inheritedConstraints = append(inheritedConstraints, constraint)
+		} else {
+			ownConstraints = append(ownConstraints, constraint)
+		}
+	}
 
 	help += fmt.Sprintf("%s\n", describeCommandUsage(app, cmd, arguments, options, path))
 
+	if cmd.Deprecated != "" {
+		help += "\nDEPRECATED:\n"
+		help += wordwrap.Indent(cmd.Deprecated, "  ", true) + "\n"
+	}
+
 	if len(arguments) > 0 {
 		help += fmt.Sprintf("\n%s", parameters.DescribeArguments(arguments))
 	}
 
-	if len(options) > 0 {
-		help += fmt.Sprintf("\n%s", parameters.DescribeOptions(options))
+	if len(ownOptions) > 0 {
+		help += fmt.Sprintf("\n%s", parameters.DescribeOptions(ownOptions, ownConstraints...))
+	}
+
+	if len(inheritedOptions) > 0 {
+		help += fmt.Sprintf("\n%s", parameters.DescribeInheritedOptions(inheritedOptions, inheritedConstraints...))
 	}
 
-	if len(cmd.commands) > 0 {
-		help += fmt.Sprintf("\n%s", DescribeCommands(cmd.commands))
+	if len(commands) > 0 {
+		help += fmt.Sprintf("\n%s", DescribeCommands(commands))
 		help += fmt.Sprintf(
 			"\n  Run `$ %s %s COMMAND --help` for more information about a command.\n",
 			app.UsageName,
@@ -43,24 +65,73 @@ func DescribeCommand(app *Application, cmd *Command, path []string) string {
 	return help
 }
 
-// DescribeCommands describes an array of Commands to provide usage information.
+// DescribeCommands describes an array of Commands to provide usage information. Ungrouped commands
+// are described first, followed by a section for each group of commands. Hidden commands are not
+// described.
 func DescribeCommands(commands []*Command) string {
-	desc := "COMMANDS:\n"
+	var groups []*CommandGroup
+	var ungrouped []*Command
 
-	// Create array and map for specific output ordering.
-	cmdKeys := []string{}
-	cmdMap := make(map[string]*Command)
+	grouped := make(map[string][]*Command)
 
 	var width int
-	for _, cmd := range commands {
-		cmdKeys = append(cmdKeys, cmd.Name)
-		cmdMap[cmd.Name] = cmd
-
+	for _, cmd := range visibleCommands(commands) {
 		len := len(cmd.Name)
 
 		if len > (width - 2) {
 			width = len + 2
 		}
+
+		if cmd.Group == nil {
+			ungrouped = append(ungrouped, cmd)
+			continue
+		}
+
+		if _, ok := grouped[cmd.Group.Title]; !ok {
+			groups = append(groups, cmd.Group)
+		}
+
+		grouped[cmd.Group.Title] = append(grouped[cmd.Group.Title], cmd)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Order == groups[j].Order {
+			return groups[i].Title < groups[j].Title
+		}
+
+		return groups[i].Order < groups[j].Order
+	})
+
+	// Ungrouped commands keep the plain title, so that help looks the same when groups aren't used.
+	var desc string
+	if len(ungrouped) > 0 || len(groups) == 0 {
+		desc += "COMMANDS:\n" + describeCommandList(ungrouped, width)
+	}
+
+	for _, group := range groups {
+		if desc != "" {
+			desc += "\n"
+		}
+
+		desc += fmt.Sprintf("COMMANDS (%s):\n", group.Title)
+		desc += describeCommandList(grouped[group.Title], width)
+	}
+
+	return desc
+}
+
+// describeCommandList describes each of the given commands on it's own line, in alphabetical order,
+// with their descriptions aligned to the given width.
+func describeCommandList(commands []*Command, width int) string {
+	var desc string
+
+	// Create array and map for specific output ordering.
+	cmdKeys := []string{}
+	cmdMap := make(map[string]*Command)
+
+	for _, cmd := range commands {
+		cmdKeys = append(cmdKeys, cmd.Name)
+		cmdMap[cmd.Name] = cmd
 	}
 
 	sort.Strings(cmdKeys)
@@ -71,14 +142,9 @@ func DescribeCommands(commands []*Command) string {
 		// Get space for the right-side of the command name.
 		spacing := width - len(name)
 
-		cmdDesc := cmd.Description
-		if cmd.Alias != "" {
-			cmdDesc = cmdDesc + fmt.Sprintf(" (Alias: %s)", cmd.Alias)
-		}
-
 		// Wrap the description onto new lines if necessary.
 		wrapper := wordwrap.Wrapper(78-width, true)
-		wrapped := wrapper(cmdDesc)
+		wrapped := wrapper(describeCommandSummary(cmd))
 
 		// Indent and prefix to produce the result.
 		prefix := fmt.Sprintf("  %s%s", cmd.Name, strings.Repeat(" ", spacing))
@@ -89,15 +155,50 @@ func DescribeCommands(commands []*Command) string {
 	return desc
 }
 
+// describeCommandSummary describes a command in a list of commands, i.e. it's description, and
+// it's alias, and whether it's deprecated, if applicable.
+func describeCommandSummary(cmd *Command) string {
+	cmdDesc := cmd.Description
+	if cmd.Alias != "" {
+		cmdDesc = cmdDesc + fmt.Sprintf(" (Alias: %s)", cmd.Alias)
+	}
+
+	if cmd.Deprecated != "" {
+		cmdDesc = cmdDesc + fmt.Sprintf(" (Deprecated: %s)", cmd.Deprecated)
+	}
+
+	return cmdDesc
+}
+
 // describeCommandUsage describes a command's usage.
 func describeCommandUsage(app *Application, cmd *Command, args []parameters.Argument, opts []parameters.Option, path []string) string {
 	desc := "USAGE:\n"
-	desc += fmt.Sprintf(
-		"  %s %s",
+	desc += "  " + describeCommandUsageLine(app, args, opts, path)
+
+	if cmd.Description != "" {
+		wrapper := wordwrap.Wrapper(78, true)
+
+		desc += "\n\n" + wordwrap.Indent(wrapper(cmd.Description), "  ", true)
+	}
+
+	return desc
+}
+
+// describeCommandUsageLine describes how a command is run, on a single line, e.g.
+// `app greet [OPTIONS...] NAME`.
+func describeCommandUsageLine(app *Application, args []parameters.Argument, opts []parameters.Option, path []string) string {
+	desc := fmt.Sprintf(
+		"%s %s",
 		app.UsageName,
 		strings.Join(path, " "),
 	)
 
+	for _, opt := range opts {
+		if opt.Required {
+			desc += " " + describeOptionUsage(opt)
+		}
+	}
+
 	if len(opts) > 0 {
 		desc += " [OPTIONS...]"
 	}
@@ -106,47 +207,102 @@ func describeCommandUsage(app *Application, cmd *Command, args []parameters.Argu
 		for _, arg := range args {
 			lb := ""
 			rb := ""
+			ellipsis := ""
 
 			if !arg.Required {
 				lb = "["
 				rb = "]"
 			}
 
-			desc += fmt.Sprintf(" %s%s%s", lb, arg.Name, rb)
+			if arg.Variadic {
+				ellipsis = "..."
+			}
+
+			desc += fmt.Sprintf(" %s%s%s%s", lb, arg.Name, ellipsis, rb)
 		}
 	}
 
-	if cmd.Description != "" {
-		wrapper := wordwrap.Wrapper(78, true)
+	return desc
+}
 
-		desc += "\n\n" + wordwrap.Indent(wrapper(cmd.Description), "  ", true)
+// describeOptionUsage describes how an option is given, e.g. `--name=NAME`.
+func describeOptionUsage(opt parameters.Option) string {
+	usage := describeOptionName(opt)
+
+	switch opt.ValueMode {
+	case parameters.OptionValueRequired:
+		usage += "=" + opt.ValueName
+	case parameters.OptionValueOptional:
+		usage += "[=" + opt.ValueName + "]"
 	}
 
-	return desc
+	return usage
 }
 
-// findCommandArguments finds all of the defined arguments on the given application and command.
-func findCommandArguments(app *Application, cmd *Command) []parameters.Argument {
-	definition := buildCommandDefinition(app, cmd)
+// visibleCommands filters out any hidden commands from the given commands.
+func visibleCommands(commands []*Command) []*Command {
+	var visible []*Command
 
-	var arguments []parameters.Argument
-	for _, arg := range definition.Arguments() {
-		arguments = append(arguments, arg)
+	for _, cmd := range commands {
+		if !cmd.Hidden {
+			visible = append(visible, cmd)
+		}
 	}
 
-	return arguments
+	return visible
 }
 
-// findCommandOptions finds all of the defined options on the given application and command.
-func findCommandOptions(app *Application, cmd *Command) []parameters.Option {
-	definition := buildCommandDefinition(app, cmd)
+// visibleArguments filters out any hidden arguments from the given arguments.
+func visibleArguments(arguments []parameters.Argument) []parameters.Argument {
+	var visible []parameters.Argument
+
+	for _, arg := range arguments {
+		if !arg.Hidden {
+			visible = append(visible, arg)
+		}
+	}
 
-	var options []parameters.Option
-	for _, opt := range definition.Options() {
-		options = append(options, opt)
+	return visible
+}
+
+// visibleOptions filters out any hidden options from the given options.
+func visibleOptions(options []parameters.Option) []parameters.Option {
+	var visible []parameters.Option
+
+	for _, opt := range options {
+		if !opt.Hidden {
+			visible = append(visible, opt)
+		}
 	}
 
-	return options
+	return visible
+}
+
+// splitInheritedOptions splits the given options into those declared by the command itself, or the
+// application, and those inherited from an ancestor of the command.
+func splitInheritedOptions(options []parameters.Option) ([]parameters.Option, []parameters.Option) {
+	var own, inherited []parameters.Option
+
+	for _, opt := range options {
+		if opt.Inherited {
+			inherited = append(inherited, opt)
+		} else {
+			own = append(own, opt)
+		}
+	}
+
+	return own, inherited
+}
+
+// isInheritedConstraint reports whether all of the options a constraint applies to are inherited.
+func isInheritedConstraint(definition *Definition, constraint parameters.OptionConstraint) bool {
+	for _, name := range constraint.Names {
+		if !definition.options[name].Inherited {
+			return false
+		}
+	}
+
+	return true
 }
 
 // buildCommandDefinition creates a definition for a given command, using the application and the
@@ -155,10 +311,7 @@ func buildCommandDefinition(app *Application, cmd *Command) *Definition {
 	definition := NewDefinition()
 
 	app.configure(definition)
-
-	if cmd != nil && cmd.Configure != nil {
-		cmd.Configure(definition)
-	}
+	app.configureCommand(definition, cmd, app.findCommandPath(cmd))
 
 	return definition
 }
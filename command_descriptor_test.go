@@ -113,6 +113,49 @@ func TestDescribeCommand(t *testing.T) {
 		assert.True(t, strings.Contains(result, "[STRING_ARG_S2]"), "Expected argument name.")
 	})
 
+	t.Run("should show variadic arguments with an ellipsis", func(t *testing.T) {
+		var ss1 []string
+
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+		command := console.Command{
+			Name: "test-command-name",
+			Configure: func(definition *console.Definition) {
+				definition.AddArgument(console.ArgumentDefinition{
+					Value: parameters.NewStringSliceValue(&ss1),
+					Spec:  "[FILES...]",
+				})
+			},
+		}
+
+		result := console.DescribeCommand(application, &command, []string{command.Name})
+
+		assert.True(t, strings.Contains(result, "[FILES...]"), "Expected argument name.")
+	})
+
+	t.Run("should show required options in the usage", func(t *testing.T) {
+		var s1 string
+
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+		application.UsageName = "app"
+
+		command := console.Command{
+			Name: "test-command-name",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value:    parameters.NewStringValue(&s1),
+					Spec:     "-c, --cluster=CLUSTER",
+					Required: true,
+				})
+			},
+		}
+
+		result := console.DescribeCommand(application, &command, []string{command.Name})
+
+		expected := "app test-command-name --cluster=CLUSTER [OPTIONS...]"
+
+		assert.True(t, strings.Contains(result, expected), "Expected required option in usage.")
+	})
+
 	t.Run("should show that there are options if there are any", func(t *testing.T) {
 		// @TODO: Update with global options implementation.
 		//var s1 string
@@ -161,6 +204,98 @@ func TestDescribeCommand(t *testing.T) {
 		assert.True(t, strings.Contains(result, subCommand.Name), "Expected sub-command name")
 		assert.True(t, strings.Contains(result, subCommand.Description), "Expected sub-command desc")
 	})
+
+	t.Run("should not show hidden sub-commands, arguments, or options", func(t *testing.T) {
+		var value string
+
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+
+		command := console.Command{
+			Name: "test-command-name",
+			Configure: func(definition *console.Definition) {
+				definition.AddArgument(console.ArgumentDefinition{
+					Value:  parameters.NewStringValue(&value),
+					Spec:   "[SECRETARG]",
+					Hidden: true,
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value:  parameters.NewStringValue(&value),
+					Spec:   "--secret-opt=VALUE",
+					Hidden: true,
+				})
+			},
+		}
+
+		command.AddCommand(&console.Command{Name: "secret-cmd", Hidden: true})
+
+		result := console.DescribeCommand(application, &command, []string{command.Name})
+
+		assert.False(t, strings.Contains(result, "SECRETARG"), "Expected no hidden argument.")
+		assert.False(t, strings.Contains(result, "ARGUMENTS:"), "Expected no arguments title.")
+		assert.False(t, strings.Contains(result, "--secret-opt"), "Expected no hidden option.")
+		assert.False(t, strings.Contains(result, "secret-cmd"), "Expected no hidden sub-command.")
+		assert.False(t, strings.Contains(result, "COMMANDS:"), "Expected no commands title.")
+	})
+
+	t.Run("should show inherited options in their own section", func(t *testing.T) {
+		var value string
+
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+
+		parent := &console.Command{
+			Name: "cluster",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value:      parameters.NewStringValue(&value),
+					Spec:       "--context=CONTEXT",
+					Persistent: true,
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringValue(&value),
+					Spec:  "--region=REGION",
+				})
+			},
+		}
+
+		command := &console.Command{
+			Name: "nodes",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringValue(&value),
+					Spec:  "--label=LABEL",
+				})
+			},
+		}
+
+		parent.AddCommand(command)
+		application.AddCommand(parent)
+
+		result := console.DescribeCommand(application, command, []string{"cluster", "nodes"})
+
+		ownIdx := strings.Index(result, "\nOPTIONS:")
+		inheritedIdx := strings.Index(result, "INHERITED OPTIONS:")
+
+		assert.True(t, ownIdx >= 0, "Expected options section.")
+		assert.True(t, inheritedIdx > ownIdx, "Expected inherited options section after options.")
+		assert.True(t, strings.Index(result, "--label") < inheritedIdx, "Expected own option.")
+		assert.True(t, strings.Index(result, "--context") > inheritedIdx, "Expected inherited option.")
+		assert.False(t, strings.Contains(result, "--region"), "Expected no non-persistent option.")
+	})
+
+	t.Run("should show that the command is deprecated if it is", func(t *testing.T) {
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+		command := console.Command{
+			Name:       "test-command-name",
+			Deprecated: "use 'other-command-name' instead",
+		}
+
+		result := console.DescribeCommand(application, &command, []string{command.Name})
+
+		assert.True(t, strings.Contains(result, "DEPRECATED:"), "Expected deprecated header.")
+		assert.True(t, strings.Contains(result, command.Deprecated), "Expected deprecation message.")
+	})
 }
 
 func TestDescribeCommands(t *testing.T) {
@@ -233,4 +368,58 @@ func TestDescribeCommands(t *testing.T) {
 		assert.True(t, strings.Contains(result, "(Alias: f)"), "Expected command description.")
 		assert.False(t, strings.Contains(result, "(Alias: b"), "Expected no command description.")
 	})
+
+	t.Run("should show a section for each group of commands, in order", func(t *testing.T) {
+		debugging := &console.CommandGroup{Title: "Debugging", Order: 2}
+		clusters := &console.CommandGroup{Title: "Cluster management", Order: 1}
+
+		result := console.DescribeCommands([]*console.Command{
+			{Name: "logs", Description: "Logs.", Group: debugging},
+			{Name: "version", Description: "Version."},
+			{Name: "nodes", Description: "Nodes.", Group: clusters},
+			{Name: "trace", Description: "Trace.", Group: debugging},
+			{Name: "clusters", Description: "Clusters.", Group: clusters},
+		})
+
+		expected := "COMMANDS:\n" +
+			"  version   Version.\n" +
+			"\n" +
+			"COMMANDS (Cluster management):\n" +
+			"  clusters  Clusters.\n" +
+			"  nodes     Nodes.\n" +
+			"\n" +
+			"COMMANDS (Debugging):\n" +
+			"  logs      Logs.\n" +
+			"  trace     Trace.\n"
+
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("should omit the default section if every command is grouped", func(t *testing.T) {
+		group := &console.CommandGroup{Title: "Debugging"}
+
+		result := console.DescribeCommands([]*console.Command{
+			{Name: "logs", Group: group},
+		})
+
+		assert.False(t, strings.Contains(result, "COMMANDS:"), "Expected no default section.")
+		assert.True(t, strings.Contains(result, "COMMANDS (Debugging):"), "Expected group section.")
+	})
+
+	t.Run("should not show hidden commands", func(t *testing.T) {
+		result := console.DescribeCommands([]*console.Command{
+			{Name: "version", Description: "Version."},
+			{Name: "experiment", Description: "Experiment.", Hidden: true},
+		})
+
+		assert.Equal(t, "COMMANDS:\n  version  Version.\n", result)
+	})
+
+	t.Run("should show deprecated commands as deprecated", func(t *testing.T) {
+		result := console.DescribeCommands([]*console.Command{
+			{Name: "ls", Description: "List nodes.", Deprecated: "use 'nodes list' instead"},
+		})
+
+		assert.True(t, strings.Contains(result, "List nodes. (Deprecated: use 'nodes list' instead)"), "Expected annotation.")
+	})
 }
@@ -0,0 +1,320 @@
+package console
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// CompatibilityChange is a single difference between two versions of an application's command-line
+// interface.
+type CompatibilityChange struct {
+	// The path of the command the change applies to, empty for the application itself.
+	Path []string
+	// What changed, e.g. "option '--format' was removed".
+	Message string
+}
+
+// String describes the change, and the command it applies to.
+func (c CompatibilityChange) String() string {
+	if len(c.Path) == 0 {
+		return c.Message
+	}
+
+	return fmt.Sprintf("%s: %s", strings.Join(c.Path, " "), c.Message)
+}
+
+// CompatibilityReport is the result of comparing two versions of an application's command-line
+// interface. Breaking changes may break existing usage, e.g. scripts, and additive ones don't.
+// Other changes don't break existing usage either, e.g. renamed arguments.
+type CompatibilityReport struct {
+	Breaking []CompatibilityChange
+	Additive []CompatibilityChange
+	Other    []CompatibilityChange
+}
+
+// IsBreaking reports whether any of the changes may break existing usage.
+func (r CompatibilityReport) IsBreaking() bool {
+	return len(r.Breaking) > 0
+}
+
+// String describes each of the changes, breaking changes first.
+func (r CompatibilityReport) String() string {
+	var desc string
+
+	if len(r.Breaking) > 0 {
+		desc += "BREAKING CHANGES:\n"
+		for _, change := range r.Breaking {
+			desc += "  " + change.String() + "\n"
+		}
+	}
+
+	if len(r.Additive) > 0 {
+		if desc != "" {
+			desc += "\n"
+		}
+
+		desc += "ADDITIVE CHANGES:\n"
+		for _, change := range r.Additive {
+			desc += "  " + change.String() + "\n"
+		}
+	}
+
+	if len(r.Other) > 0 {
+		if desc != "" {
+			desc += "\n"
+		}
+
+		desc += "OTHER CHANGES:\n"
+		for _, change := range r.Other {
+			desc += "  " + change.String() + "\n"
+		}
+	}
+
+	return desc
+}
+
+// breaking records a breaking change to the command with the given path.
+func (r *CompatibilityReport) breaking(path []string, format string, args ...interface{}) {
+	r.Breaking = append(r.Breaking, CompatibilityChange{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// additive records an additive change to the command with the given path.
+func (r *CompatibilityReport) additive(path []string, format string, args ...interface{}) {
+	r.Additive = append(r.Additive, CompatibilityChange{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// other records a change to the command with the given path that is neither breaking, nor additive.
+func (r *CompatibilityReport) other(path []string, format string, args ...interface{}) {
+	r.Other = append(r.Other, CompatibilityChange{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// WriteSnapshot records the command-line interface of the given application to a file, as JSON, so
+// that later versions can be compared against it. See ExportJSON.
+func WriteSnapshot(app *Application, path string) error {
+	data, err := ExportJSON(app)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// ReadSnapshot reads a command-line interface recorded by WriteSnapshot.
+func ReadSnapshot(path string) (ExportedApplication, error) {
+	var snapshot ExportedApplication
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return snapshot, err
+	}
+
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return snapshot, fmt.Errorf("console: Invalid snapshot '%s': %w", path, err)
+	}
+
+	return snapshot, nil
+}
+
+// CheckCompatibility compares the command-line interface of the given application against the
+// snapshot recorded in the file at the given path.
+func CheckCompatibility(app *Application, path string) (CompatibilityReport, error) {
+	snapshot, err := ReadSnapshot(path)
+	if err != nil {
+		return CompatibilityReport{}, err
+	}
+
+	return CompareExports(snapshot, ExportApplication(app)), nil
+}
+
+// CompareExports compares a previous version of an application's command-line interface with the
+// current one. Removed command names, aliases, and option names, options that now require a value,
+// or are required, arguments that became required, were removed, or no longer accept multiple
+// values, and commands that can no longer be executed are breaking changes. Added commands,
+// aliases, options, and optional arguments are additive changes. Renamed commands and arguments
+// are other changes, though a command's old name must remain as an alias to not be breaking.
+func CompareExports(previous ExportedApplication, current ExportedApplication) CompatibilityReport {
+	var report CompatibilityReport
+
+	compareOptions(&report, nil, previous.Options, current.Options)
+	compareCommands(&report, previous.Commands, current.Commands)
+
+	return report
+}
+
+// compareCommands compares previous and current versions of a list of commands, and their
+// sub-commands. Commands are matched by their names and aliases together, so that a command that is
+// renamed, keeping it's old name as an alias, is still matched.
+func compareCommands(report *CompatibilityReport, previous []ExportedCommand, current []ExportedCommand) {
+	currentByName := make(map[string]ExportedCommand)
+	for _, cmd := range current {
+		for _, name := range exportedCommandNames(cmd) {
+			currentByName[name] = cmd
+		}
+	}
+
+	previousByName := make(map[string]ExportedCommand)
+
+	for _, previousCmd := range previous {
+		var currentCmd ExportedCommand
+		var found bool
+
+		for _, name := range exportedCommandNames(previousCmd) {
+			previousByName[name] = previousCmd
+
+			if cmd, ok := currentByName[name]; ok && !found {
+				currentCmd, found = cmd, true
+			}
+		}
+
+		if !found {
+			report.breaking(previousCmd.Path, "command was removed")
+			continue
+		}
+
+		compareCommand(report, previousCmd, currentCmd)
+	}
+
+	for _, currentCmd := range current {
+		var found bool
+
+		for _, name := range exportedCommandNames(currentCmd) {
+			if _, ok := previousByName[name]; ok {
+				found = true
+			}
+		}
+
+		if !found {
+			report.additive(currentCmd.Path, "command was added")
+		}
+	}
+}
+
+// compareCommand compares previous and current versions of a single command, and it's sub-commands.
+func compareCommand(report *CompatibilityReport, previous ExportedCommand, current ExportedCommand) {
+	path := current.Path
+
+	previousNames := exportedCommandNames(previous)
+	currentNames := exportedCommandNames(current)
+
+	if previous.Name != current.Name {
+		report.other(path, "command was renamed from '%s'", previous.Name)
+
+		if !containsString(currentNames, previous.Name) {
+			report.breaking(path, "name '%s' was removed", previous.Name)
+		}
+	}
+
+	if previous.Alias != "" && !containsString(currentNames, previous.Alias) {
+		report.breaking(path, "alias '%s' was removed", previous.Alias)
+	}
+
+	if current.Alias != "" && !containsString(previousNames, current.Alias) {
+		report.additive(path, "alias '%s' was added", current.Alias)
+	}
+
+	if previous.Executable && !current.Executable {
+		report.breaking(path, "command can no longer be executed")
+	}
+
+	compareOptions(report, path, previous.Options, current.Options)
+	compareArguments(report, path, previous.Arguments, current.Arguments)
+	compareCommands(report, previous.Commands, current.Commands)
+}
+
+// compareOptions compares previous and current versions of the options of a command, by each of
+// their names.
+func compareOptions(report *CompatibilityReport, path []string, previous []ExportedOption, current []ExportedOption) {
+	currentByName := make(map[string]ExportedOption)
+	for _, opt := range current {
+		for _, name := range opt.Names {
+			currentByName[name] = opt
+		}
+	}
+
+	previousByName := make(map[string]ExportedOption)
+
+	for _, previousOpt := range previous {
+		var compared bool
+
+		for _, name := range previousOpt.Names {
+			previousByName[name] = previousOpt
+
+			currentOpt, ok := currentByName[name]
+			if !ok {
+				report.breaking(path, "option '%s' was removed", formatOptionName(name))
+				continue
+			}
+
+			// Only report changes to the option as a whole once, by the first name that remains.
+			if compared {
+				continue
+			}
+
+			compared = true
+
+			if previousOpt.ValueMode != "required" && currentOpt.ValueMode == "required" {
+				report.breaking(path, "option '%s' now requires a value", formatOptionName(name))
+			}
+
+			if previousOpt.ValueMode != "none" && currentOpt.ValueMode == "none" {
+				report.breaking(path, "option '%s' no longer accepts a value", formatOptionName(name))
+			}
+
+			if !previousOpt.Required && currentOpt.Required {
+				report.breaking(path, "option '%s' is now required", formatOptionName(name))
+			}
+		}
+	}
+
+	for _, currentOpt := range current {
+		for _, name := range currentOpt.Names {
+			if _, ok := previousByName[name]; !ok {
+				report.additive(path, "option '%s' was added", formatOptionName(name))
+			}
+		}
+	}
+}
+
+// compareArguments compares previous and current versions of the arguments of a command, by
+// position, as arguments are given by position. Renaming an argument doesn't break existing usage.
+func compareArguments(report *CompatibilityReport, path []string, previous []ExportedArgument, current []ExportedArgument) {
+	for i, previousArg := range previous {
+		if i >= len(current) {
+			report.breaking(path, "argument '%s' was removed", previousArg.Name)
+			continue
+		}
+
+		currentArg := current[i]
+
+		if previousArg.Name != currentArg.Name {
+			report.other(path, "argument at position %d was renamed from '%s' to '%s'", i+1, previousArg.Name, currentArg.Name)
+		}
+
+		if !previousArg.Required && currentArg.Required {
+			report.breaking(path, "argument '%s' is now required", currentArg.Name)
+		}
+
+		if previousArg.Variadic && !currentArg.Variadic {
+			report.breaking(path, "argument '%s' no longer accepts multiple values", currentArg.Name)
+		}
+	}
+
+	for i := len(previous); i < len(current); i++ {
+		if current[i].Required {
+			report.breaking(path, "required argument '%s' was added", current[i].Name)
+		} else {
+			report.additive(path, "argument '%s' was added", current[i].Name)
+		}
+	}
+}
+
+// exportedCommandNames gets the name of an exported command, and it's alias, if it has one.
+func exportedCommandNames(cmd ExportedCommand) []string {
+	if cmd.Alias == "" {
+		return []string{cmd.Name}
+	}
+
+	return []string{cmd.Name, cmd.Alias}
+}
@@ -0,0 +1,215 @@
+package console_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/eidolon/console"
+	"github.com/seeruk/assert"
+)
+
+func TestCompareExports(t *testing.T) {
+	createExport := func() console.ExportedApplication {
+		return console.ExportedApplication{
+			Options: []console.ExportedOption{
+				{Names: []string{"h", "help"}, ValueMode: "none"},
+			},
+			Commands: []console.ExportedCommand{
+				{
+					Name:       "cluster",
+					Path:       []string{"cluster"},
+					Alias:      "c",
+					Executable: true,
+					Arguments: []console.ExportedArgument{
+						{Name: "NAME", Required: true},
+						{Name: "REGION"},
+					},
+					Options: []console.ExportedOption{
+						{Names: []string{"f", "format"}, ValueMode: "optional"},
+						{Names: []string{"verbose"}, ValueMode: "none"},
+					},
+					Commands: []console.ExportedCommand{
+						{Name: "list", Path: []string{"cluster", "list"}, Executable: true},
+					},
+				},
+			},
+		}
+	}
+
+	messages := func(changes []console.CompatibilityChange) []string {
+		var result []string
+		for _, change := range changes {
+			result = append(result, change.String())
+		}
+
+		return result
+	}
+
+	t.Run("should report nothing if nothing changed", func(t *testing.T) {
+		report := console.CompareExports(createExport(), createExport())
+
+		assert.False(t, report.IsBreaking(), "Expected no breaking changes.")
+		assert.Equal(t, 0, len(report.Additive))
+		assert.Equal(t, "", report.String())
+	})
+
+	t.Run("should report removed commands, aliases, and option names as breaking", func(t *testing.T) {
+		current := createExport()
+		current.Commands[0].Alias = ""
+		current.Commands[0].Commands = nil
+		current.Commands[0].Options[0].Names = []string{"format"}
+		current.Options = nil
+
+		report := console.CompareExports(createExport(), current)
+
+		expected := []string{
+			"option '-h' was removed",
+			"option '--help' was removed",
+			"cluster: alias 'c' was removed",
+			"cluster: option '-f' was removed",
+			"cluster list: command was removed",
+		}
+
+		assert.Equal(t, expected, messages(report.Breaking))
+	})
+
+	t.Run("should report options and arguments that became required as breaking", func(t *testing.T) {
+		current := createExport()
+		current.Commands[0].Options[0].ValueMode = "required"
+		current.Commands[0].Options[1].Required = true
+		current.Commands[0].Arguments[1].Required = true
+		current.Commands[0].Arguments = append(current.Commands[0].Arguments, console.ExportedArgument{
+			Name:     "ZONE",
+			Required: true,
+		})
+
+		report := console.CompareExports(createExport(), current)
+
+		expected := []string{
+			"cluster: option '-f' now requires a value",
+			"cluster: option '--verbose' is now required",
+			"cluster: argument 'REGION' is now required",
+			"cluster: required argument 'ZONE' was added",
+		}
+
+		assert.Equal(t, expected, messages(report.Breaking))
+	})
+
+	t.Run("should report removed arguments, and commands that can't be executed as breaking", func(t *testing.T) {
+		current := createExport()
+		current.Commands[0].Executable = false
+		current.Commands[0].Arguments = current.Commands[0].Arguments[:1]
+
+		report := console.CompareExports(createExport(), current)
+
+		expected := []string{
+			"cluster: command can no longer be executed",
+			"cluster: argument 'REGION' was removed",
+		}
+
+		assert.Equal(t, expected, messages(report.Breaking))
+	})
+
+	t.Run("should report renamed arguments as other changes", func(t *testing.T) {
+		current := createExport()
+		current.Commands[0].Arguments = []console.ExportedArgument{
+			{Name: "NAME", Required: true},
+			{Name: "ZONE"},
+			{Name: "REGION"},
+		}
+
+		report := console.CompareExports(createExport(), current)
+
+		assert.Equal(t, 0, len(report.Breaking))
+		assert.Equal(t, []string{"cluster: argument 'REGION' was added"}, messages(report.Additive))
+		assert.Equal(t, []string{"cluster: argument at position 2 was renamed from 'REGION' to 'ZONE'"}, messages(report.Other))
+	})
+
+	t.Run("should still report breaking changes to renamed arguments", func(t *testing.T) {
+		current := createExport()
+		current.Commands[0].Arguments[1] = console.ExportedArgument{Name: "ZONE", Required: true}
+
+		report := console.CompareExports(createExport(), current)
+
+		assert.Equal(t, []string{"cluster: argument 'ZONE' is now required"}, messages(report.Breaking))
+		assert.Equal(t, []string{"cluster: argument at position 2 was renamed from 'REGION' to 'ZONE'"}, messages(report.Other))
+	})
+
+	t.Run("should match renamed commands that keep their old name as an alias", func(t *testing.T) {
+		current := createExport()
+		current.Commands[0].Name = "clusters"
+		current.Commands[0].Path = []string{"clusters"}
+		current.Commands[0].Alias = "cluster"
+		current.Commands[0].Commands[0].Path = []string{"clusters", "list"}
+
+		report := console.CompareExports(createExport(), current)
+
+		assert.Equal(t, []string{"clusters: alias 'c' was removed"}, messages(report.Breaking))
+		assert.Equal(t, 0, len(report.Additive))
+		assert.Equal(t, []string{"clusters: command was renamed from 'cluster'"}, messages(report.Other))
+	})
+
+	t.Run("should report renamed commands that don't keep their old name as breaking", func(t *testing.T) {
+		current := createExport()
+		current.Commands[0].Name = "clusters"
+		current.Commands[0].Path = []string{"clusters"}
+
+		report := console.CompareExports(createExport(), current)
+
+		assert.Equal(t, []string{"clusters: name 'cluster' was removed"}, messages(report.Breaking))
+		assert.Equal(t, 0, len(report.Additive))
+		assert.Equal(t, []string{"clusters: command was renamed from 'cluster'"}, messages(report.Other))
+	})
+
+	t.Run("should report additions separately", func(t *testing.T) {
+		current := createExport()
+		current.Commands[0].Alias = "cl"
+		current.Commands[0].Options[1].Names = []string{"v", "verbose"}
+		current.Commands[0].Arguments = append(current.Commands[0].Arguments, console.ExportedArgument{Name: "ZONE"})
+		current.Commands = append(current.Commands, console.ExportedCommand{Name: "nodes", Path: []string{"nodes"}})
+
+		report := console.CompareExports(createExport(), current)
+
+		assert.Equal(t, []string{"cluster: alias 'c' was removed"}, messages(report.Breaking))
+
+		expected := []string{
+			"cluster: alias 'cl' was added",
+			"cluster: option '-v' was added",
+			"cluster: argument 'ZONE' was added",
+			"nodes: command was added",
+		}
+
+		assert.Equal(t, expected, messages(report.Additive))
+	})
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	t.Run("should compare an application against a snapshot", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cli.json")
+
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+		application.AddCommand(&console.Command{Name: "cluster", Alias: "c"})
+
+		err := console.WriteSnapshot(application, path)
+		assert.OK(t, err)
+
+		report, err := console.CheckCompatibility(application, path)
+		assert.OK(t, err)
+		assert.False(t, report.IsBreaking(), "Expected no breaking changes.")
+
+		application = console.NewApplication("eidolon/console", "1.2.4+testing")
+		application.AddCommand(&console.Command{Name: "cluster"})
+
+		report, err = console.CheckCompatibility(application, path)
+		assert.OK(t, err)
+		assert.True(t, report.IsBreaking(), "Expected breaking changes.")
+		assert.Equal(t, "BREAKING CHANGES:\n  cluster: alias 'c' was removed\n", report.String())
+	})
+
+	t.Run("should error if the snapshot can't be read", func(t *testing.T) {
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+
+		_, err := console.CheckCompatibility(application, filepath.Join(t.TempDir(), "missing.json"))
+		assert.NotOK(t, err)
+	})
+}
@@ -0,0 +1,319 @@
+package console
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/eidolon/console/parameters"
+)
+
+// completeCommandName is the name of the hidden command that shells call at runtime to find
+// completion candidates. It is intercepted by Application.Run, and never shown in help output.
+const completeCommandName = "__complete"
+
+// completionShells maps supported shell names to their completion script templates.
+var completionShells = map[string]string{
+	"bash": bashCompletionTemplate,
+	"fish": fishCompletionTemplate,
+	"zsh":  zshCompletionTemplate,
+}
+
+// GenerateCompletion produces a completion script for the given shell (bash, zsh, or fish). The
+// generated scripts are thin wrappers around the hidden `__complete` command, so the candidates
+// they offer are always found by walking the application's current command tree and definitions,
+// and never drift from the real CLI. If there are no candidates, e.g. for values that can't
+// suggest any, the scripts fall back to completing files.
+func GenerateCompletion(app *Application, shell string) (string, error) {
+	template, ok := completionShells[shell]
+	if !ok {
+		return "", fmt.Errorf("console: Unsupported completion shell '%s'", shell)
+	}
+
+	replacer := strings.NewReplacer(
+		"{{name}}", app.UsageName,
+		"{{func}}", completionFuncName(app.UsageName),
+		"{{complete}}", completeCommandName,
+	)
+
+	return replacer.Replace(template), nil
+}
+
+// NewCompletionCommand creates a `completion SHELL` command that prints the completion script for
+// the given application. It still needs to be added to the application.
+func NewCompletionCommand(app *Application) *Command {
+	var shell string
+
+	var shells []string
+	for name := range completionShells {
+		shells = append(shells, name)
+	}
+
+	sort.Strings(shells)
+
+	return &Command{
+		Name:        "completion",
+		Description: "Output a shell completion script.",
+		Help: fmt.Sprintf(
+			"Load completions in your current shell with e.g. `source <(%s completion bash)`.",
+			app.UsageName,
+		),
+		Configure: func(definition *Definition) {
+			definition.AddArgument(ArgumentDefinition{
+				Value: parameters.NewEnumValue(&shell, shells...),
+				Spec:  "SHELL",
+				Desc:  fmt.Sprintf("The shell to generate a script for (%s).", strings.Join(shells, ", ")),
+			})
+		},
+		Execute: func(input *Input, output *Output) error {
+			script, err := GenerateCompletion(app, shell)
+			if err != nil {
+				return err
+			}
+
+			// The script is written as it is, it's never markup.
+			fmt.Fprint(output.Writer, script)
+
+			return nil
+		},
+	}
+}
+
+// completion is a single completion candidate, with an optional description.
+type completion struct {
+	Value       string
+	Description string
+}
+
+// String formats a completion candidate the way the completion scripts expect it.
+func (c completion) String() string {
+	if c.Description == "" {
+		return c.Value
+	}
+
+	return c.Value + "\t" + c.Description
+}
+
+// complete writes the completion candidates for the given words to the application output, as they
+// are. Markup is stripped from descriptions, as shells can't show it. The last word is the one
+// currently being completed, and may be empty.
+func (a *Application) complete(words []string) int {
+	for _, candidate := range findCompletions(a, words) {
+		candidate.Description = stripMarkup(a, candidate.Description)
+
+		fmt.Fprintln(a.output.Writer, candidate.String())
+	}
+
+	return 0
+}
+
+// findCompletions finds completion candidates for the last of the given words, by walking the
+// command tree with the preceding words, and inspecting the resolved command's definition.
+func findCompletions(app *Application, words []string) []completion {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+
+	current := words[len(words)-1]
+	preceding := words[:len(words)-1]
+
+	cmd, path := app.resolveCommand(preceding)
+	preceding = preceding[len(path):]
+
+	definition := buildCommandDefinition(app, cmd)
+
+	// If the previous word is an option that requires a value, then we're completing that value.
+	if len(preceding) > 0 {
+		if opt, ok := findOptionByRawName(definition, preceding[len(preceding)-1]); ok {
+			if opt.ValueMode == parameters.OptionValueRequired {
+				return completeValue(opt.Value, "", current)
+			}
+		}
+	}
+
+	// Values given inline, e.g. `--name=foo`, keep the option in the completed word.
+	if strings.HasPrefix(current, "-") && strings.Contains(current, "=") {
+		split := strings.SplitN(current, "=", 2)
+
+		if opt, ok := findOptionByRawName(definition, split[0]); ok {
+			return completeValue(opt.Value, split[0]+"=", split[1])
+		}
+
+		return nil
+	}
+
+	if strings.HasPrefix(current, "-") {
+		return completeOptions(definition, current)
+	}
+
+	var container CommandContainer = app
+	if cmd != nil {
+		container = cmd
+	}
+
+	input := ParseInput2(definition, preceding)
+
+	var completions []completion
+
+	// Sub-commands can only be given before any of the arguments of the command that contains them,
+	// which may take arguments too, so both are completed.
+	if len(input.Arguments) == 0 {
+		completions = completeCommands(container.Commands(), current)
+	}
+
+	return append(completions, completeArgument(definition, input, current)...)
+}
+
+// completeArgument finds values for the positional argument that the given input would be given to
+// next, if there is one, that start with prefix.
+func completeArgument(definition *Definition, input *Input, prefix string) []completion {
+	arguments := definition.Arguments()
+
+	var argument *parameters.Argument
+	if len(input.Arguments) < len(arguments) {
+		argument = &arguments[len(input.Arguments)]
+	} else if len(arguments) > 0 && arguments[len(arguments)-1].Variadic {
+		argument = &arguments[len(arguments)-1]
+	}
+
+	if argument == nil || argument.Hidden {
+		return nil
+	}
+
+	return completeValue(argument.Value, "", prefix)
+}
+
+// completeCommands finds the names and aliases of the given commands that start with prefix.
+func completeCommands(commands []*Command, prefix string) []completion {
+	var completions []completion
+
+	for _, cmd := range visibleCommands(commands) {
+		if strings.HasPrefix(cmd.Name, prefix) {
+			completions = append(completions, completion{cmd.Name, cmd.Description})
+		}
+
+		if cmd.Alias != "" && strings.HasPrefix(cmd.Alias, prefix) {
+			completions = append(completions, completion{cmd.Alias, cmd.Description})
+		}
+	}
+
+	return completions
+}
+
+// completeOptions finds the names of the options in the given definition that start with prefix.
+func completeOptions(definition *Definition, prefix string) []completion {
+	var completions []completion
+
+	for _, opt := range visibleOptions(definition.Options()) {
+		for _, name := range opt.Names {
+			if name = formatOptionName(name); strings.HasPrefix(name, prefix) {
+				completions = append(completions, completion{name, opt.Description})
+			}
+		}
+	}
+
+	return completions
+}
+
+// completeValue asks a value for candidates, if it is able to suggest any. Each candidate is
+// prepended with the given prefix.
+func completeValue(value parameters.Value, prefix string, current string) []completion {
+	completer, ok := value.(parameters.Completer)
+	if !ok {
+		return nil
+	}
+
+	var completions []completion
+	for _, candidate := range completer.Complete(current) {
+		completions = append(completions, completion{Value: prefix + candidate})
+	}
+
+	return completions
+}
+
+// findOptionByRawName finds an option in a definition by a name as it would be given in raw input,
+// i.e. with leading hyphens.
+func findOptionByRawName(definition *Definition, raw string) (parameters.Option, bool) {
+	isLongOpt := len(raw) > 2 && strings.HasPrefix(raw, "--")
+	isShortOpt := len(raw) == 2 && strings.HasPrefix(raw, "-")
+
+	if !isLongOpt && !isShortOpt {
+		return parameters.Option{}, false
+	}
+
+	opt, ok := definition.options[strings.TrimLeft(raw, "-")]
+
+	return opt, ok
+}
+
+// completionFuncName creates a valid shell function name from an application's usage name.
+func completionFuncName(name string) string {
+	return "_" + regexp.MustCompile("[^A-Za-z0-9_]").ReplaceAllString(name, "_") + "_completion"
+}
+
+const bashCompletionTemplate = `# bash completion for {{name}}
+{{func}}() {
+    local line="${COMP_LINE:0:COMP_POINT}"
+    local -a words
+    read -ra words <<< "${line}"
+    [[ "${line}" =~ [[:space:]]$ ]] && words+=("")
+
+    local cur="${words[${#words[@]}-1]}"
+    local IFS=$'\n'
+
+    COMPREPLY=($(compgen -W "$({{name}} {{complete}} "${words[@]:1}" 2>/dev/null | cut -f1)" -- "${cur}"))
+
+    # Bash treats '=' as a word break, so trim anything it already considers typed.
+    local typed="${cur%"${COMP_WORDS[COMP_CWORD]}"}"
+    COMPREPLY=("${COMPREPLY[@]#"${typed}"}")
+}
+
+complete -o default -F {{func}} {{name}}
+`
+
+const zshCompletionTemplate = `#compdef {{name}}
+# zsh completion for {{name}}
+{{func}}() {
+    local -a lines completions
+    local line value desc
+
+    lines=("${(@f)$({{name}} {{complete}} "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+
+    for line in "${lines[@]}"; do
+        [[ -z "${line}" ]] && continue
+        value="${line%%$'\t'*}"
+        desc=""
+        [[ "${line}" == *$'\t'* ]] && desc="${line#*$'\t'}"
+        completions+=("${value//:/\\:}:${desc}")
+    done
+
+    # Fall back to completing files if there are no candidates, as bash does with '-o default'.
+    if (( ${#completions} == 0 )); then
+        _files
+        return
+    fi
+
+    _describe -t values '{{name}}' completions
+}
+
+compdef {{func}} {{name}}
+`
+
+const fishCompletionTemplate = `# fish completion for {{name}}
+function {{func}}
+    set -l tokens (commandline -opc)
+    set -l current (commandline -ct | string collect -a)
+    set -l candidates ({{name}} {{complete}} $tokens[2..-1] $current 2>/dev/null)
+
+    # Fall back to completing files if there are no candidates, as bash does with '-o default'.
+    if test (count $candidates) -eq 0
+        __fish_complete_path $current
+        return
+    end
+
+    printf '%s\n' $candidates
+end
+
+complete -c {{name}} -f -a '({{func}})'
+`
@@ -0,0 +1,258 @@
+package console_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+	"github.com/seeruk/assert"
+)
+
+func TestGenerateCompletion(t *testing.T) {
+	t.Run("should generate scripts for supported shells", func(t *testing.T) {
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+		application.UsageName = "eidolon-console"
+
+		for _, shell := range []string{"bash", "zsh", "fish"} {
+			script, err := console.GenerateCompletion(application, shell)
+			assert.OK(t, err)
+
+			assert.True(t, strings.Contains(script, "eidolon-console __complete"), "Expected callback.")
+			assert.True(t, strings.Contains(script, "_eidolon_console_completion"), "Expected func.")
+		}
+	})
+
+	t.Run("should fall back to completing files if there are no candidates", func(t *testing.T) {
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+
+		fallbacks := map[string]string{
+			"bash": "complete -o default",
+			"zsh":  "_files",
+			"fish": "__fish_complete_path $current",
+		}
+
+		for shell, fallback := range fallbacks {
+			script, err := console.GenerateCompletion(application, shell)
+			assert.OK(t, err)
+			assert.True(t, strings.Contains(script, fallback), "Expected file completion fallback for "+shell+".")
+		}
+	})
+
+	t.Run("should error for unsupported shells", func(t *testing.T) {
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+
+		_, err := console.GenerateCompletion(application, "tcsh")
+		assert.NotOK(t, err)
+	})
+}
+
+func TestNewCompletionCommand(t *testing.T) {
+	t.Run("should print the completion script for the given shell", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+		application.UsageName = "eidolon-console"
+		application.Writer = &writer
+		application.AddCommand(console.NewCompletionCommand(application))
+
+		code := application.Run([]string{"completion", "fish"}, []string{})
+		assert.Equal(t, 0, code)
+
+		expected, err := console.GenerateCompletion(application, "fish")
+		assert.OK(t, err)
+		assert.Equal(t, expected, writer.String())
+	})
+
+	t.Run("should fail for unsupported shells", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+		application.Writer = &writer
+		application.AddCommand(console.NewCompletionCommand(application))
+
+		code := application.Run([]string{"completion", "tcsh"}, []string{})
+		assert.Equal(t, 101, code)
+	})
+}
+
+func TestApplicationComplete(t *testing.T) {
+	createApplication := func(writer *bytes.Buffer) *console.Application {
+		var format string
+		var name string
+		var env string
+		var target string
+
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+		application.Writer = writer
+
+		cluster := &console.Command{
+			Name:        "cluster",
+			Alias:       "c",
+			Description: "Manage clusters.",
+		}
+
+		cluster.AddCommand(&console.Command{
+			Name:        "list",
+			Description: "List clusters.",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewEnumValue(&format, "json", "table"),
+					Spec:  "-f, --format=FORMAT",
+					Desc:  "Output format.",
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringValue(&name),
+					Spec:  "--name=NAME",
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value:  parameters.NewStringValue(&name),
+					Spec:   "--filter=FILTER",
+					Hidden: true,
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewPathValue(&name),
+					Spec:  "--input=FILE",
+				})
+
+				definition.AddArgument(console.ArgumentDefinition{
+					Value: parameters.NewEnumValue(&env, "production", "staging"),
+					Spec:  "[ENVIRONMENT]",
+				})
+			},
+			Execute: func(input *console.Input, output *console.Output) error {
+				return nil
+			},
+		})
+
+		deploy := &console.Command{
+			Name: "deploy",
+			Configure: func(definition *console.Definition) {
+				definition.AddArgument(console.ArgumentDefinition{
+					Value: parameters.NewEnumValue(&target, "production", "preview"),
+					Spec:  "[TARGET]",
+				})
+			},
+			Execute: func(input *console.Input, output *console.Output) error {
+				return nil
+			},
+		}
+
+		deploy.AddCommand(&console.Command{
+			Name:        "status",
+			Description: "Show deployment status.",
+		})
+
+		application.AddCommand(cluster)
+		application.AddCommand(deploy)
+		application.AddCommand(&console.Command{Name: "config"})
+		application.AddCommand(&console.Command{Name: "canary", Hidden: true})
+
+		return application
+	}
+
+	complete := func(words ...string) []string {
+		writer := bytes.Buffer{}
+		application := createApplication(&writer)
+
+		code := application.Run(append([]string{"__complete"}, words...), []string{})
+		if code != 0 {
+			return nil
+		}
+
+		return strings.Split(strings.TrimSpace(writer.String()), "\n")
+	}
+
+	t.Run("should complete top-level command names and aliases", func(t *testing.T) {
+		result := complete("c")
+
+		assert.Equal(t, []string{"cluster\tManage clusters.", "c\tManage clusters.", "config"}, result)
+	})
+
+	t.Run("should complete sub-command names", func(t *testing.T) {
+		result := complete("c", "")
+
+		assert.Equal(t, []string{"list\tList clusters."}, result)
+	})
+
+	t.Run("should complete sub-command names and arguments of commands that have both", func(t *testing.T) {
+		assert.Equal(t, []string{"status\tShow deployment status.", "production", "preview"}, complete("deploy", ""))
+		assert.Equal(t, []string{"production", "preview"}, complete("deploy", "p"))
+	})
+
+	t.Run("should not complete sub-command names after arguments", func(t *testing.T) {
+		assert.Equal(t, []string{""}, complete("deploy", "production", "s"))
+	})
+
+	t.Run("should complete option names", func(t *testing.T) {
+		result := complete("cluster", "list", "--f")
+
+		assert.Equal(t, []string{"--format\tOutput format."}, result)
+	})
+
+	t.Run("should include global options", func(t *testing.T) {
+		result := complete("cluster", "list", "--h")
+
+		assert.Equal(t, []string{"--help\tDisplay contextual help?"}, result)
+	})
+
+	t.Run("should complete option values given as the next word", func(t *testing.T) {
+		result := complete("cluster", "list", "-f", "")
+
+		assert.Equal(t, []string{"json", "table"}, result)
+	})
+
+	t.Run("should complete option values given inline", func(t *testing.T) {
+		result := complete("cluster", "list", "--format=t")
+
+		assert.Equal(t, []string{"--format=table"}, result)
+	})
+
+	t.Run("should complete argument values", func(t *testing.T) {
+		result := complete("cluster", "list", "--name", "foo", "s")
+
+		assert.Equal(t, []string{"staging"}, result)
+	})
+
+	t.Run("should complete paths", func(t *testing.T) {
+		dir := t.TempDir()
+
+		err := os.WriteFile(filepath.Join(dir, "clusters.yaml"), nil, 0644)
+		assert.OK(t, err)
+
+		result := complete("cluster", "list", "--input", filepath.Join(dir, "cl"))
+
+		assert.Equal(t, []string{filepath.Join(dir, "clusters.yaml")}, result)
+	})
+
+	t.Run("should not complete values that can't suggest candidates", func(t *testing.T) {
+		result := complete("cluster", "list", "--name", "")
+
+		assert.Equal(t, []string{""}, result)
+	})
+
+	t.Run("should strip markup from descriptions", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+		application.Writer = &writer
+		application.Markup = true
+		application.AddCommand(&console.Command{Name: "cluster", Description: "Manage <b>clusters</b>."})
+
+		code := application.Run([]string{"__complete", "cl"}, []string{"FORCE_COLOR=1"})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "cluster\tManage clusters.\n", writer.String())
+	})
+
+	t.Run("should not complete hidden commands or options", func(t *testing.T) {
+		assert.Equal(t, []string{"config"}, complete("co"))
+		assert.Equal(t, []string{""}, complete("cluster", "list", "--fi"))
+	})
+}
@@ -0,0 +1,225 @@
+package console
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eidolon/console/config"
+	"github.com/eidolon/console/parameters"
+)
+
+// configOptionName is the name of the global option used to give an explicit config file.
+const configOptionName = "config"
+
+// configExtensions are the extensions of config files looked for in config directories, in order.
+var configExtensions = []string{".yaml", ".yml", ".json", ".toml", ".ini"}
+
+// configureConfig adds the global option used to give an explicit config file, if config files are
+// enabled.
+func (a *Application) configureConfig(definition *Definition) {
+	if a.ConfigName == "" && a.ConfigEnvVar == "" {
+		return
+	}
+
+	var file string
+
+	definition.AddOption(OptionDefinition{
+		Value:  parameters.NewStringValue(&file),
+		Spec:   "--" + configOptionName + "=FILE",
+		Desc:   "Path to a config file to read option values from.",
+		EnvVar: a.ConfigEnvVar,
+	})
+}
+
+// loadConfig reads the option values that apply to the command at the given path from config
+// files. Values in top-level keys come first, followed by values in the command's section, so that
+// the command's section takes precedence. Later files take precedence over earlier ones.
+func (a *Application) loadConfig(path []string, env []string) ([]InputConfigValue, error) {
+	if a.ConfigName == "" && a.ConfigEnvVar == "" {
+		return nil, nil
+	}
+
+	var topLevel []InputConfigValue
+	var command []InputConfigValue
+
+	for _, file := range a.findConfigFiles(parseEnv(env)) {
+		entries, err := config.ParseFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		t, c := resolveConfig(file, entries, path, a.commands)
+
+		topLevel = append(topLevel, t...)
+		command = append(command, c...)
+	}
+
+	return append(topLevel, command...), nil
+}
+
+// findConfigFiles finds the config files to read, least important first. A file given explicitly
+// by the config option, or it's environment variables, is used instead of looking in the XDG config
+// directories.
+func (a *Application) findConfigFiles(env map[string]string) []string {
+	if values := a.input.GetOptionValues([]string{configOptionName}); len(values) > 0 {
+		return values[len(values)-1:]
+	}
+
+	if opt, ok := a.definition.options[configOptionName]; ok {
+		for _, envVar := range opt.EnvVars {
+			if file, ok := env[envVar]; ok && file != "" {
+				return []string{file}
+			}
+		}
+	}
+
+	if a.ConfigName == "" {
+		return nil
+	}
+
+	dirs := filepath.SplitList(env["XDG_CONFIG_DIRS"])
+	if len(dirs) == 0 {
+		dirs = []string{"/etc/xdg"}
+	}
+
+	home := env["XDG_CONFIG_HOME"]
+	if home == "" && env["HOME"] != "" {
+		home = filepath.Join(env["HOME"], ".config")
+	}
+
+	// XDG_CONFIG_DIRS is ordered most important first, and XDG_CONFIG_HOME is more important than
+	// all of them.
+	var candidates []string
+	for i := len(dirs) - 1; i >= 0; i-- {
+		candidates = append(candidates, dirs[i])
+	}
+
+	if home != "" {
+		candidates = append(candidates, home)
+	}
+
+	var files []string
+
+	for _, dir := range candidates {
+		for _, ext := range configExtensions {
+			file := filepath.Join(dir, a.ConfigName, "config"+ext)
+
+			if info, err := os.Stat(file); err == nil && !info.IsDir() {
+				files = append(files, file)
+				break
+			}
+		}
+	}
+
+	return files
+}
+
+// resolveConfig picks out the entries read from a config file that apply to the command at the
+// given path. Entries are split into those from the top-level of the file, and those from the
+// command's section. Sections nested one level further are read as `key=value` pairs, for options
+// that reference a parameters.StringMapValue. Sections are resolved through the given command tree,
+// by name or alias, so the sections of other commands, at any depth, never apply to the command
+// being run.
+func resolveConfig(file string, entries []config.Entry, path []string, commands []*Command) ([]InputConfigValue, []InputConfigValue) {
+	var topLevel []InputConfigValue
+	var command []InputConfigValue
+
+	for _, entry := range entries {
+		value := InputConfigValue{
+			Name:   entry.Key,
+			Key:    entry.Name(),
+			Values: entry.Values,
+			File:   file,
+			Line:   entry.Line,
+		}
+
+		sectionPath, rest := resolveConfigSection(commands, entry.Section)
+
+		switch {
+		case len(rest) > 1:
+			continue
+		case len(sectionPath) == 0 && len(rest) == 0:
+			topLevel = append(topLevel, value)
+		case len(sectionPath) == 0:
+			topLevel = addConfigPairs(topLevel, entry, value)
+		case !equalPath(sectionPath, path):
+			continue
+		case len(rest) == 0:
+			command = append(command, value)
+		default:
+			command = addConfigPairs(command, entry, value)
+		}
+	}
+
+	return topLevel, command
+}
+
+// addConfigPairs adds the values of an entry nested in a section to the value named after that
+// section, as `key=value` pairs.
+func addConfigPairs(values []InputConfigValue, entry config.Entry, value InputConfigValue) []InputConfigValue {
+	name := entry.Section[len(entry.Section)-1]
+
+	var pairs []string
+	for _, v := range entry.Values {
+		pairs = append(pairs, entry.Key+"="+v)
+	}
+
+	for i := range values {
+		if values[i].Name == name && values[i].File == value.File {
+			values[i].Values = append(values[i].Values, pairs...)
+			return values
+		}
+	}
+
+	value.Name = name
+	value.Key = strings.Join(entry.Section, ".")
+	value.Values = pairs
+
+	return append(values, value)
+}
+
+// resolveConfigSection follows the given section through the given command tree, by name or
+// alias, for as long as it names commands. The path of command names it reaches, and the rest of the
+// section, are returned.
+func resolveConfigSection(commands []*Command, section []string) ([]string, []string) {
+	var path []string
+
+	for i, name := range section {
+		command := findCommandByName(commands, name)
+		if command == nil {
+			return path, section[i:]
+		}
+
+		path = append(path, command.Name)
+		commands = command.Commands()
+	}
+
+	return path, nil
+}
+
+// findCommandByName finds the command with the given name or alias in the given commands, if any.
+func findCommandByName(commands []*Command, name string) *Command {
+	for _, command := range commands {
+		if command.Name == name || command.Alias == name {
+			return command
+		}
+	}
+
+	return nil
+}
+
+// equalPath reports whether the given section and command path are the same.
+func equalPath(section []string, path []string) bool {
+	if len(section) != len(path) {
+		return false
+	}
+
+	for i := range section {
+		if section[i] != path[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Entry represents a single key read from a configuration file, and it's value(s).
+type Entry struct {
+	// The path of sections the key is nested in. Empty for top-level keys.
+	Section []string
+	// The name of the key.
+	Key string
+	// The value(s) of the key. Lists of values produce more than one value.
+	Values []string
+	// The line the key was read from, or 0 if it's unknown.
+	Line int
+}
+
+// Name gets the full, dot-separated name of the entry's key, including it's section.
+func (e Entry) Name() string {
+	return strings.Join(append(append([]string{}, e.Section...), e.Key), ".")
+}
+
+// Error describes a problem with a configuration file, and where in the file it was found.
+type Error struct {
+	// The path of the file the problem was found in.
+	File string
+	// The line the problem was found on, or 0 if it's unknown.
+	Line int
+	// The full name of the key the problem was found with, if there is one.
+	Key string
+	// A description of the problem.
+	Message string
+}
+
+// Error formats the problem, and it's location.
+func (e *Error) Error() string {
+	location := e.File
+	if e.Line > 0 {
+		location += fmt.Sprintf(":%d", e.Line)
+	}
+
+	if e.Key != "" {
+		return fmt.Sprintf("config: %s: key '%s': %s", location, e.Key, e.Message)
+	}
+
+	return fmt.Sprintf("config: %s: %s", location, e.Message)
+}
+
+// ParseFunc is a function that parses the contents of a configuration file into Entries.
+type ParseFunc func(data []byte) ([]Entry, error)
+
+// Extensions maps supported file extensions to the ParseFunc used to parse files with them.
+var Extensions = map[string]ParseFunc{
+	".ini":  ParseINI,
+	".json": ParseJSON,
+	".toml": ParseTOML,
+	".yaml": ParseYAML,
+	".yml":  ParseYAML,
+}
+
+// ParseFile reads and parses the configuration file at the given path. The format of the file is
+// chosen based on it's extension.
+func ParseFile(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &Error{File: path, Message: err.Error()}
+	}
+
+	return Parse(path, data)
+}
+
+// Parse parses the given contents of a configuration file. The format is chosen based on the
+// extension of the given path.
+func Parse(path string, data []byte) ([]Entry, error) {
+	parse, ok := Extensions[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return nil, &Error{File: path, Message: "unsupported file extension"}
+	}
+
+	entries, err := parse(data)
+	if cerr, ok := err.(*Error); ok {
+		cerr.File = path
+	}
+
+	return entries, err
+}
+
+// errorf creates an Error for the given line, with a formatted message.
+func errorf(line int, format string, a ...interface{}) *Error {
+	return &Error{Line: line, Message: fmt.Sprintf(format, a...)}
+}
+
+// subSection creates the path of a section nested in the given section, without modifying it. The
+// result is nil for the top level.
+func subSection(section []string, keys ...string) []string {
+	if len(section)+len(keys) == 0 {
+		return nil
+	}
+
+	return append(append([]string{}, section...), keys...)
+}
+
+// splitList splits a list or table body on the given separator, ignoring separators inside quotes.
+// Each item is trimmed, and empty items are dropped.
+func splitList(s string, sep rune) []string {
+	var items []string
+	var quote rune
+	var start int
+
+	for i, r := range s {
+		switch {
+		case quote != 0 && r == quote && !isEscaped(s, i):
+			quote = 0
+		case quote == 0 && (r == '"' || r == '\''):
+			quote = r
+		case quote == 0 && r == sep:
+			items = append(items, s[start:i])
+			start = i + 1
+		}
+	}
+
+	items = append(items, s[start:])
+
+	var trimmed []string
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			trimmed = append(trimmed, item)
+		}
+	}
+
+	return trimmed
+}
+
+// stripComment removes a trailing comment that starts with one of the given markers from a line,
+// ignoring markers inside quotes. Markers must be at the start of the line, or follow whitespace.
+func stripComment(line string, markers string) string {
+	var quote rune
+
+	for i, r := range line {
+		switch {
+		case quote != 0 && r == quote && !isEscaped(line, i):
+			quote = 0
+		case quote == 0 && (r == '"' || r == '\''):
+			quote = r
+		case quote == 0 && strings.ContainsRune(markers, r):
+			if i == 0 || line[i-1] == ' ' || line[i-1] == '\t' {
+				return strings.TrimRight(line[:i], " \t")
+			}
+		}
+	}
+
+	return line
+}
+
+// indexOutsideQuotes gets the index of the first occurrence of the given rune in s that isn't
+// inside quotes, or -1 if there isn't one.
+func indexOutsideQuotes(s string, c rune) int {
+	var quote rune
+
+	for i, r := range s {
+		switch {
+		case quote != 0 && r == quote && !isEscaped(s, i):
+			quote = 0
+		case quote == 0 && (r == '"' || r == '\''):
+			quote = r
+		case quote == 0 && r == c:
+			return i
+		}
+	}
+
+	return -1
+}
+
+// isEscaped reports whether the byte at the given index is preceded by an odd number of
+// backslashes.
+func isEscaped(s string, i int) bool {
+	var count int
+	for j := i - 1; j >= 0 && s[j] == '\\'; j-- {
+		count++
+	}
+
+	return count%2 == 1
+}
@@ -0,0 +1,79 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eidolon/console/config"
+	"github.com/seeruk/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("should choose a parser based on the file extension", func(t *testing.T) {
+		files := map[string]string{
+			"config.json": `{"greet": {"name": "Bob"}}`,
+			"config.yaml": "greet:\n  name: Bob\n",
+			"config.yml":  "greet:\n  name: Bob\n",
+			"config.toml": "[greet]\nname = \"Bob\"\n",
+			"config.ini":  "[greet]\nname = Bob\n",
+		}
+
+		for path, data := range files {
+			entries, err := config.Parse(path, []byte(data))
+			assert.OK(t, err)
+			assert.Equal(t, 1, len(entries))
+			assert.Equal(t, "greet.name", entries[0].Name())
+			assert.Equal(t, []string{"Bob"}, entries[0].Values)
+		}
+	})
+
+	t.Run("should error for unsupported file extensions", func(t *testing.T) {
+		_, err := config.Parse("config.xml", []byte("<greet/>"))
+		assert.NotOK(t, err)
+	})
+
+	t.Run("should add the file to errors", func(t *testing.T) {
+		_, err := config.Parse("config.ini", []byte("[greet\n"))
+		assert.NotOK(t, err)
+
+		cerr, ok := err.(*config.Error)
+		assert.True(t, ok, "Expected a *config.Error.")
+		assert.Equal(t, "config.ini", cerr.File)
+		assert.Equal(t, 1, cerr.Line)
+	})
+}
+
+func TestParseFile(t *testing.T) {
+	t.Run("should read and parse files", func(t *testing.T) {
+		dir, err := os.MkdirTemp("", "console-config")
+		assert.OK(t, err)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "config.ini")
+		assert.OK(t, os.WriteFile(path, []byte("name = Bob\n"), 0644))
+
+		entries, err := config.ParseFile(path)
+		assert.OK(t, err)
+		assert.Equal(t, []config.Entry{{Key: "name", Values: []string{"Bob"}, Line: 1}}, entries)
+	})
+
+	t.Run("should error if the file can't be read", func(t *testing.T) {
+		_, err := config.ParseFile("/path/that/does/not/exist.yaml")
+		assert.NotOK(t, err)
+	})
+}
+
+func TestError(t *testing.T) {
+	t.Run("Error()", func(t *testing.T) {
+		t.Run("should include the file, line, and key", func(t *testing.T) {
+			err := config.Error{File: "config.yaml", Line: 3, Key: "greet.name", Message: "invalid"}
+			assert.Equal(t, "config: config.yaml:3: key 'greet.name': invalid", err.Error())
+		})
+
+		t.Run("should omit unknown parts of the location", func(t *testing.T) {
+			err := config.Error{File: "config.yaml", Message: "invalid"}
+			assert.Equal(t, "config: config.yaml: invalid", err.Error())
+		})
+	})
+}
@@ -0,0 +1,7 @@
+// Package config contains parsers for the configuration files that option values may be read from.
+// JSON, YAML, TOML, and INI files are supported. Each parser only supports the subset of it's format
+// that is useful for option values: nested sections, scalar values, and lists of scalar values.
+//
+// Every parser produces a flat list of Entries, each of which remembers the line it was read from,
+// so that problems with values can be reported precisely.
+package config
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"strings"
+)
+
+// ParseINI parses an INI configuration file. Sections may be nested by separating their names
+// with dots, e.g. `[cluster.list]`. Keys that are given more than once in the same section produce
+// a list of values. Lines starting with `;` or `#` are comments.
+func ParseINI(data []byte) ([]Entry, error) {
+	var entries []Entry
+	var section []string
+
+	// Index of entries by their full name, so that repeated keys can be combined.
+	index := make(map[string]int)
+
+	for i, line := range strings.Split(string(data), "\n") {
+		lineNum := i + 1
+		line = strings.TrimSpace(line)
+
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+
+		if line[0] == '[' {
+			if line[len(line)-1] != ']' {
+				return nil, errorf(lineNum, "expected ']' at the end of section header")
+			}
+
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if name == "" {
+				return nil, errorf(lineNum, "expected a section name")
+			}
+
+			section = nil
+			for _, part := range strings.Split(name, ".") {
+				section = append(section, strings.TrimSpace(part))
+			}
+
+			continue
+		}
+
+		pair := strings.SplitN(line, "=", 2)
+		if len(pair) != 2 {
+			return nil, errorf(lineNum, "expected 'key = value'")
+		}
+
+		key := strings.TrimSpace(pair[0])
+		if key == "" {
+			return nil, errorf(lineNum, "expected a key before '='")
+		}
+
+		value := unquoteINIValue(stripComment(strings.TrimSpace(pair[1]), ";#"))
+
+		entry := Entry{Section: section, Key: key, Values: []string{value}, Line: lineNum}
+		if idx, ok := index[entry.Name()]; ok {
+			entries[idx].Values = append(entries[idx].Values, value)
+			continue
+		}
+
+		index[entry.Name()] = len(entries)
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// unquoteINIValue removes matching quotes from around a value, if it has them.
+func unquoteINIValue(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
@@ -0,0 +1,41 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/eidolon/console/config"
+	"github.com/seeruk/assert"
+)
+
+func TestParseINI(t *testing.T) {
+	t.Run("should parse keys, sections, and repeated keys", func(t *testing.T) {
+		data := `; comment
+verbose = true
+
+[cluster.list]
+# comment
+format = "json"
+tag = a
+tag = b ; trailing comment
+`
+
+		entries, err := config.ParseINI([]byte(data))
+		assert.OK(t, err)
+		assert.Equal(t, []config.Entry{
+			{Key: "verbose", Values: []string{"true"}, Line: 2},
+			{Section: []string{"cluster", "list"}, Key: "format", Values: []string{"json"}, Line: 6},
+			{Section: []string{"cluster", "list"}, Key: "tag", Values: []string{"a", "b"}, Line: 7},
+		}, entries)
+	})
+
+	t.Run("should error for invalid lines", func(t *testing.T) {
+		_, err := config.ParseINI([]byte("name = Bob\nnonsense\n"))
+		assert.NotOK(t, err)
+		assert.Equal(t, 2, err.(*config.Error).Line)
+	})
+
+	t.Run("should error for unclosed section headers", func(t *testing.T) {
+		_, err := config.ParseINI([]byte("[greet\n"))
+		assert.NotOK(t, err)
+	})
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+)
+
+// ParseJSON parses a JSON configuration file. The file must contain an object. Nested objects are
+// treated as sections, and arrays of scalar values as lists. Null values are ignored. Keys are read
+// in sorted order, and lines are only known for syntax errors.
+func ParseJSON(data []byte) ([]Entry, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		var serr *json.SyntaxError
+		if errors.As(err, &serr) {
+			return nil, errorf(lineAt(data, serr.Offset), "%s", serr.Error())
+		}
+
+		return nil, errorf(lineAt(data, int64(len(data))), "%s", err.Error())
+	}
+
+	if decoder.More() {
+		return nil, errorf(lineAt(data, decoder.InputOffset()), "unexpected content after the top level object")
+	}
+
+	object, ok := document.(map[string]interface{})
+	if !ok {
+		return nil, errorf(0, "expected an object at the top level")
+	}
+
+	return addJSONEntries(nil, nil, object)
+}
+
+// addJSONEntries adds an entry for each of the keys of the given object to entries, in the given
+// section. Keys with object values are added as sections.
+func addJSONEntries(entries []Entry, section []string, object map[string]interface{}) ([]Entry, error) {
+	var keys []string
+	for key := range object {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		entry := Entry{Section: section, Key: key}
+
+		switch value := object[key].(type) {
+		case nil:
+			continue
+		case map[string]interface{}:
+			var err error
+
+			entries, err = addJSONEntries(entries, subSection(section, key), value)
+			if err != nil {
+				return nil, err
+			}
+
+			continue
+		case []interface{}:
+			for _, item := range value {
+				switch item.(type) {
+				case nil:
+					continue
+				case map[string]interface{}, []interface{}:
+					return nil, &Error{Key: entry.Name(), Message: "lists may only contain scalar values"}
+				}
+
+				entry.Values = append(entry.Values, formatJSONScalar(item))
+			}
+		default:
+			entry.Values = []string{formatJSONScalar(value)}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// lineAt gets the line number of the given byte offset in the given data.
+func lineAt(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// formatJSONScalar converts a scalar JSON value into a string.
+func formatJSONScalar(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case string:
+		return v
+	}
+
+	return ""
+}
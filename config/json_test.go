@@ -0,0 +1,53 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/eidolon/console/config"
+	"github.com/seeruk/assert"
+)
+
+func TestParseJSON(t *testing.T) {
+	t.Run("should parse keys, sections, and lists", func(t *testing.T) {
+		data := `{
+  "verbose": true,
+  "greet": {
+    "name": "Bob",
+    "count": 3,
+    "tags": ["a", "b"],
+    "skipped": null
+  }
+}`
+
+		entries, err := config.ParseJSON([]byte(data))
+		assert.OK(t, err)
+		assert.Equal(t, []config.Entry{
+			{Section: []string{"greet"}, Key: "count", Values: []string{"3"}},
+			{Section: []string{"greet"}, Key: "name", Values: []string{"Bob"}},
+			{Section: []string{"greet"}, Key: "tags", Values: []string{"a", "b"}},
+			{Key: "verbose", Values: []string{"true"}},
+		}, entries)
+	})
+
+	t.Run("should report the line of syntax errors", func(t *testing.T) {
+		_, err := config.ParseJSON([]byte("{\n  \"name\": \"Bob\",\n  oops\n}"))
+		assert.NotOK(t, err)
+		assert.Equal(t, 3, err.(*config.Error).Line)
+	})
+
+	t.Run("should error if the top level isn't an object", func(t *testing.T) {
+		_, err := config.ParseJSON([]byte(`["a"]`))
+		assert.NotOK(t, err)
+	})
+
+	t.Run("should error for nested values in lists", func(t *testing.T) {
+		_, err := config.ParseJSON([]byte("{\n  \"tags\": [{\"a\": 1}]\n}"))
+		assert.NotOK(t, err)
+		assert.Equal(t, "tags", err.(*config.Error).Key)
+	})
+
+	t.Run("should error for content after the top level object", func(t *testing.T) {
+		_, err := config.ParseJSON([]byte("{}\n{}"))
+		assert.NotOK(t, err)
+	})
+}
@@ -0,0 +1,233 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseTOML parses a TOML configuration file. Tables are treated as sections, and arrays of scalar
+// values as lists. Inline tables may only contain scalar values. Multi-line strings and arrays of
+// tables aren't supported.
+func ParseTOML(data []byte) ([]Entry, error) {
+	var entries []Entry
+	var section []string
+
+	lines := strings.Split(string(data), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		lineNum := i + 1
+		line := strings.TrimSpace(stripComment(lines[i], "#"))
+
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[[") {
+			return nil, errorf(lineNum, "arrays of tables are not supported")
+		}
+
+		if line[0] == '[' {
+			if line[len(line)-1] != ']' {
+				return nil, errorf(lineNum, "expected ']' at the end of table header")
+			}
+
+			keys, err := parseTOMLKey(line[1 : len(line)-1])
+			if err != nil {
+				return nil, errorf(lineNum, "%s", err)
+			}
+
+			section = keys
+			continue
+		}
+
+		eq := indexOutsideQuotes(line, '=')
+		if eq < 0 {
+			return nil, errorf(lineNum, "expected 'key = value'")
+		}
+
+		keys, err := parseTOMLKey(line[:eq])
+		if err != nil {
+			return nil, errorf(lineNum, "%s", err)
+		}
+
+		entry := Entry{
+			Section: subSection(section, keys[:len(keys)-1]...),
+			Key:     keys[len(keys)-1],
+			Line:    lineNum,
+		}
+
+		value := strings.TrimSpace(line[eq+1:])
+
+		// Arrays may span multiple lines, so keep consuming lines until the brackets balance.
+		for strings.HasPrefix(value, "[") && !balanced(value) && i+1 < len(lines) {
+			i++
+			value += " " + strings.TrimSpace(stripComment(lines[i], "#"))
+		}
+
+		switch {
+		case value == "":
+			return nil, &Error{Line: lineNum, Key: entry.Name(), Message: "expected a value"}
+		case value[0] == '{':
+			inline, err := parseTOMLInlineTable(entry, value)
+			if err != nil {
+				return nil, err
+			}
+
+			entries = append(entries, inline...)
+			continue
+		case value[0] == '[':
+			if !balanced(value) || value[len(value)-1] != ']' {
+				return nil, &Error{Line: lineNum, Key: entry.Name(), Message: "expected ']' at the end of array"}
+			}
+
+			for _, item := range splitList(value[1:len(value)-1], ',') {
+				scalar, err := parseTOMLScalar(item)
+				if err != nil {
+					return nil, &Error{Line: lineNum, Key: entry.Name(), Message: err.Error()}
+				}
+
+				entry.Values = append(entry.Values, scalar)
+			}
+		default:
+			scalar, err := parseTOMLScalar(value)
+			if err != nil {
+				return nil, &Error{Line: lineNum, Key: entry.Name(), Message: err.Error()}
+			}
+
+			entry.Values = []string{scalar}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// parseTOMLInlineTable parses an inline table into an entry for each of it's keys, in a section
+// named after the given entry.
+func parseTOMLInlineTable(entry Entry, value string) ([]Entry, error) {
+	if value[len(value)-1] != '}' {
+		return nil, &Error{Line: entry.Line, Key: entry.Name(), Message: "expected '}' at the end of inline table"}
+	}
+
+	var entries []Entry
+
+	section := subSection(entry.Section, entry.Key)
+
+	for _, pair := range splitList(value[1:len(value)-1], ',') {
+		eq := indexOutsideQuotes(pair, '=')
+		if eq < 0 {
+			return nil, &Error{Line: entry.Line, Key: entry.Name(), Message: "expected 'key = value' in inline table"}
+		}
+
+		keys, err := parseTOMLKey(pair[:eq])
+		if err != nil {
+			return nil, &Error{Line: entry.Line, Key: entry.Name(), Message: err.Error()}
+		}
+
+		inline := Entry{
+			Section: subSection(section, keys[:len(keys)-1]...),
+			Key:     keys[len(keys)-1],
+			Line:    entry.Line,
+		}
+
+		scalar, serr := parseTOMLScalar(strings.TrimSpace(pair[eq+1:]))
+		if serr != nil {
+			return nil, &Error{Line: entry.Line, Key: inline.Name(), Message: serr.Error()}
+		}
+
+		inline.Values = []string{scalar}
+		entries = append(entries, inline)
+	}
+
+	return entries, nil
+}
+
+// parseTOMLKey parses a (possibly dotted) key into it's parts.
+func parseTOMLKey(key string) ([]string, error) {
+	var keys []string
+
+	parts := splitList(key, '.')
+	if len(parts) == 0 {
+		return nil, errors.New("expected a key")
+	}
+
+	for _, part := range parts {
+		switch {
+		case part[0] == '"' || part[0] == '\'':
+			unquoted, err := parseTOMLScalar(part)
+			if err != nil {
+				return nil, err
+			}
+
+			keys = append(keys, unquoted)
+		default:
+			for _, r := range part {
+				if !isBareKeyRune(r) {
+					return nil, fmt.Errorf("invalid key '%s'", part)
+				}
+			}
+
+			keys = append(keys, part)
+		}
+	}
+
+	return keys, nil
+}
+
+// parseTOMLScalar parses a string, boolean, number, or date value into a string.
+func parseTOMLScalar(value string) (string, error) {
+	switch {
+	case value == "":
+		return "", errors.New("expected a value")
+	case strings.HasPrefix(value, `"""`) || strings.HasPrefix(value, "'''"):
+		return "", errors.New("multi-line strings are not supported")
+	case value[0] == '"':
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid string %s", value)
+		}
+
+		return unquoted, nil
+	case value[0] == '\'':
+		if len(value) < 2 || value[len(value)-1] != '\'' || strings.Contains(value[1:len(value)-1], "'") {
+			return "", fmt.Errorf("invalid string %s", value)
+		}
+
+		return value[1 : len(value)-1], nil
+	case value == "true" || value == "false":
+		return value, nil
+	case strings.ContainsAny(value[:1], "0123456789+-") || value == "inf" || value == "nan":
+		return strings.Replace(value, "_", "", -1), nil
+	}
+
+	return "", fmt.Errorf("invalid value '%s'", value)
+}
+
+// isBareKeyRune reports whether the given rune may be used in a bare key.
+func isBareKeyRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-'
+}
+
+// balanced reports whether the brackets in the given value are balanced, ignoring any in quotes.
+func balanced(value string) bool {
+	var depth int
+	var quote rune
+
+	for i, r := range value {
+		switch {
+		case quote != 0 && r == quote && !isEscaped(value, i):
+			quote = 0
+		case quote == 0 && (r == '"' || r == '\''):
+			quote = r
+		case quote == 0 && r == '[':
+			depth++
+		case quote == 0 && r == ']':
+			depth--
+		}
+	}
+
+	return depth == 0
+}
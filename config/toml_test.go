@@ -0,0 +1,53 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/eidolon/console/config"
+	"github.com/seeruk/assert"
+)
+
+func TestParseTOML(t *testing.T) {
+	t.Run("should parse keys, tables, and arrays", func(t *testing.T) {
+		data := `# comment
+verbose = true
+timeout = 1_000
+
+[cluster.list]
+format = 'json' # trailing comment
+tags = [
+  "a", # first
+  "b",
+]
+labels = { env = "prod", "tier" = 'web' }
+owner.name = "Bob"
+`
+
+		entries, err := config.ParseTOML([]byte(data))
+		assert.OK(t, err)
+		assert.Equal(t, []config.Entry{
+			{Key: "verbose", Values: []string{"true"}, Line: 2},
+			{Key: "timeout", Values: []string{"1000"}, Line: 3},
+			{Section: []string{"cluster", "list"}, Key: "format", Values: []string{"json"}, Line: 6},
+			{Section: []string{"cluster", "list"}, Key: "tags", Values: []string{"a", "b"}, Line: 7},
+			{Section: []string{"cluster", "list", "labels"}, Key: "env", Values: []string{"prod"}, Line: 11},
+			{Section: []string{"cluster", "list", "labels"}, Key: "tier", Values: []string{"web"}, Line: 11},
+			{Section: []string{"cluster", "list", "owner"}, Key: "name", Values: []string{"Bob"}, Line: 12},
+		}, entries)
+	})
+
+	t.Run("should report the line and key of invalid values", func(t *testing.T) {
+		_, err := config.ParseTOML([]byte("[greet]\nname = Bob\n"))
+		assert.NotOK(t, err)
+		assert.Equal(t, 2, err.(*config.Error).Line)
+		assert.Equal(t, "greet.name", err.(*config.Error).Key)
+	})
+
+	t.Run("should error for unsupported features", func(t *testing.T) {
+		_, err := config.ParseTOML([]byte("[[servers]]\nname = \"a\"\n"))
+		assert.NotOK(t, err)
+
+		_, err = config.ParseTOML([]byte("help = \"\"\"\nlong\n\"\"\"\n"))
+		assert.NotOK(t, err)
+	})
+}
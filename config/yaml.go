@@ -0,0 +1,368 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseYAML parses a YAML configuration file. The document must be a mapping. Nested mappings are
+// treated as sections, and sequences of scalar values as lists. Flow sequences and mappings
+// (`[a, b]` and `{a: b}`) of scalar values are supported on a single line. Null values are ignored.
+// Anything else, e.g. block scalars, anchors, aliases, tags, nested flow collections, or more than
+// one document, is an error, rather than being read incorrectly.
+func ParseYAML(data []byte) ([]Entry, error) {
+	parser := yamlParser{}
+
+	for i, line := range strings.Split(string(data), "\n") {
+		text := strings.TrimRight(stripComment(line, "#"), " \t\r")
+		trimmed := strings.TrimLeft(text, " ")
+
+		if trimmed == "" {
+			continue
+		}
+
+		if isYAMLDocumentMarker(trimmed) {
+			if len(parser.lines) > 0 || trimmed != "---" {
+				return nil, errorf(i+1, "only a single document is supported")
+			}
+
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "%") {
+			return nil, errorf(i+1, "directives are not supported")
+		}
+
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, errorf(i+1, "tabs are not allowed for indentation")
+		}
+
+		parser.lines = append(parser.lines, yamlLine{
+			indent: len(text) - len(trimmed),
+			text:   trimmed,
+			num:    i + 1,
+		})
+	}
+
+	if len(parser.lines) == 0 {
+		return nil, nil
+	}
+
+	if err := parser.parseMapping(parser.lines[0].indent, nil); err != nil {
+		return nil, err
+	}
+
+	if parser.pos < len(parser.lines) {
+		return nil, errorf(parser.lines[parser.pos].num, "unexpected indentation")
+	}
+
+	return parser.entries, nil
+}
+
+// yamlLine is a non-empty line of a YAML document, with comments removed.
+type yamlLine struct {
+	indent int
+	text   string
+	num    int
+}
+
+// yamlParser parses the lines of a YAML document, one block at a time.
+type yamlParser struct {
+	lines   []yamlLine
+	pos     int
+	entries []Entry
+}
+
+// parseMapping parses a block mapping whose keys are at the given indentation.
+func (p *yamlParser) parseMapping(indent int, section []string) error {
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+
+		if line.indent < indent {
+			return nil
+		}
+
+		if line.indent > indent {
+			return errorf(line.num, "unexpected indentation")
+		}
+
+		if isYAMLSequenceItem(line.text) {
+			return errorf(line.num, "unexpected sequence item, expected 'key: value'")
+		}
+
+		key, value, err := splitYAMLKey(line.text)
+		if err != nil {
+			return errorf(line.num, "%s", err)
+		}
+
+		p.pos++
+
+		entry := Entry{Section: section, Key: key, Line: line.num}
+
+		if value != "" {
+			if err := p.parseValue(entry, value); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if p.pos >= len(p.lines) {
+			continue
+		}
+
+		next := p.lines[p.pos]
+
+		switch {
+		case isYAMLSequenceItem(next.text) && next.indent >= indent:
+			// Sequences may be at the same indentation as their key.
+			values, err := p.parseSequence(next.indent, entry)
+			if err != nil {
+				return err
+			}
+
+			entry.Values = values
+			p.entries = append(p.entries, entry)
+		case next.indent > indent:
+			if err := p.parseMapping(next.indent, subSection(section, key)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseSequence parses a block sequence of scalar values whose items are at the given indentation.
+func (p *yamlParser) parseSequence(indent int, entry Entry) ([]string, error) {
+	var values []string
+
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+
+		if line.indent < indent || line.indent == indent && !isYAMLSequenceItem(line.text) {
+			break
+		}
+
+		if line.indent > indent {
+			return nil, &Error{Line: line.num, Key: entry.Name(), Message: "sequences may only contain scalar values"}
+		}
+
+		p.pos++
+
+		item := strings.TrimSpace(line.text[1:])
+		if item == "" || item[0] == '[' || item[0] == '{' || isYAMLSequenceItem(item) {
+			return nil, &Error{Line: line.num, Key: entry.Name(), Message: "sequences may only contain scalar values"}
+		}
+
+		if _, _, err := splitYAMLKey(item); err == nil {
+			return nil, &Error{Line: line.num, Key: entry.Name(), Message: "sequences may only contain scalar values"}
+		}
+
+		value, null, err := parseYAMLScalar(item)
+		if err != nil {
+			return nil, &Error{Line: line.num, Key: entry.Name(), Message: err.Error()}
+		}
+
+		if !null {
+			values = append(values, value)
+		}
+	}
+
+	return values, nil
+}
+
+// parseValue parses a value given on the same line as it's key, and records the resulting
+// entries.
+func (p *yamlParser) parseValue(entry Entry, value string) error {
+	switch value[0] {
+	case '[':
+		if value[len(value)-1] != ']' {
+			return &Error{Line: entry.Line, Key: entry.Name(), Message: "expected ']' at the end of flow sequence"}
+		}
+
+		if isNestedYAMLFlow(value) {
+			return &Error{Line: entry.Line, Key: entry.Name(), Message: "nested flow collections are not supported"}
+		}
+
+		for _, item := range splitList(value[1:len(value)-1], ',') {
+			scalar, null, err := parseYAMLScalar(item)
+			if err != nil {
+				return &Error{Line: entry.Line, Key: entry.Name(), Message: err.Error()}
+			}
+
+			if !null {
+				entry.Values = append(entry.Values, scalar)
+			}
+		}
+	case '{':
+		if value[len(value)-1] != '}' {
+			return &Error{Line: entry.Line, Key: entry.Name(), Message: "expected '}' at the end of flow mapping"}
+		}
+
+		if isNestedYAMLFlow(value) {
+			return &Error{Line: entry.Line, Key: entry.Name(), Message: "nested flow collections are not supported"}
+		}
+
+		section := subSection(entry.Section, entry.Key)
+
+		for _, pair := range splitList(value[1:len(value)-1], ',') {
+			key, item, err := splitYAMLKey(pair)
+			if err != nil {
+				return &Error{Line: entry.Line, Key: entry.Name(), Message: err.Error()}
+			}
+
+			inline := Entry{Section: section, Key: key, Line: entry.Line}
+			if item == "" {
+				continue
+			}
+
+			if err := p.parseValue(inline, item); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		scalar, null, err := parseYAMLScalar(value)
+		if err != nil {
+			return &Error{Line: entry.Line, Key: entry.Name(), Message: err.Error()}
+		}
+
+		if null {
+			return nil
+		}
+
+		entry.Values = []string{scalar}
+	}
+
+	p.entries = append(p.entries, entry)
+
+	return nil
+}
+
+// splitYAMLKey splits a `key: value` pair into it's key and (possibly empty) value.
+func splitYAMLKey(text string) (string, string, error) {
+	switch text[0] {
+	case '?':
+		return "", "", errors.New("complex keys are not supported")
+	case '&', '*', '!':
+		return "", "", errors.New("anchors, aliases, and tags are not supported")
+	}
+
+	if text[0] == '"' || text[0] == '\'' {
+		end := closingQuote(text)
+		if end < 0 {
+			return "", "", errors.New("expected a closing quote")
+		}
+
+		key, _, err := parseYAMLScalar(text[:end+1])
+		if err != nil {
+			return "", "", err
+		}
+
+		rest := text[end+1:]
+		if !strings.HasPrefix(rest, ":") {
+			return "", "", errors.New("expected 'key: value'")
+		}
+
+		return key, strings.TrimSpace(rest[1:]), nil
+	}
+
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i == len(text)-1 || text[i+1] == ' ') {
+			key := strings.TrimSpace(text[:i])
+			if key == "" {
+				break
+			}
+
+			return key, strings.TrimSpace(text[i+1:]), nil
+		}
+	}
+
+	return "", "", errors.New("expected 'key: value'")
+}
+
+// parseYAMLScalar parses a plain, single quoted, or double quoted scalar value. Whether or not the
+// value is null is also returned.
+func parseYAMLScalar(value string) (string, bool, error) {
+	value = strings.TrimSpace(value)
+
+	switch {
+	case value == "" || value == "~" || value == "null" || value == "Null" || value == "NULL":
+		return "", true, nil
+	case value[0] == '"':
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return "", false, fmt.Errorf("invalid string %s", value)
+		}
+
+		return unquoted, false, nil
+	case value[0] == '\'':
+		if len(value) < 2 || closingQuote(value) != len(value)-1 {
+			return "", false, fmt.Errorf("invalid string %s", value)
+		}
+
+		return strings.Replace(value[1:len(value)-1], "''", "'", -1), false, nil
+	case strings.ContainsAny(value[:1], "&*!"):
+		return "", false, errors.New("anchors, aliases, and tags are not supported")
+	case strings.ContainsAny(value[:1], "|>"):
+		return "", false, errors.New("block scalars are not supported")
+	case strings.ContainsAny(value[:1], "@`%"):
+		return "", false, fmt.Errorf("plain values may not start with '%c'", value[0])
+	case isYAMLSequenceItem(value) || strings.Contains(value, ": ") || strings.HasSuffix(value, ":"):
+		return "", false, fmt.Errorf("unexpected structure in plain value %s, it may need quoting", value)
+	}
+
+	return value, false, nil
+}
+
+// isNestedYAMLFlow reports whether the given flow sequence or mapping contains another one.
+func isNestedYAMLFlow(value string) bool {
+	inner := value[1 : len(value)-1]
+
+	for _, c := range "[]{}" {
+		if indexOutsideQuotes(inner, c) >= 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isYAMLDocumentMarker reports whether the given text starts or ends a document.
+func isYAMLDocumentMarker(text string) bool {
+	for _, marker := range []string{"---", "..."} {
+		if text == marker || strings.HasPrefix(text, marker+" ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// closingQuote gets the index of the quote that closes the quoted string at the start of the given
+// text, or -1 if it isn't closed.
+func closingQuote(text string) int {
+	quote := text[0]
+
+	for i := 1; i < len(text); i++ {
+		switch {
+		case quote == '"' && text[i] == '\\':
+			i++
+		case quote == '\'' && text[i] == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			i++
+		case text[i] == quote:
+			return i
+		}
+	}
+
+	return -1
+}
+
+// isYAMLSequenceItem reports whether the given text is a block sequence item.
+func isYAMLSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
@@ -0,0 +1,86 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/eidolon/console/config"
+	"github.com/seeruk/assert"
+)
+
+func TestParseYAML(t *testing.T) {
+	t.Run("should parse keys, sections, and sequences", func(t *testing.T) {
+		data := `---
+# comment
+verbose: true
+cluster:
+  list:
+    format: "json" # trailing comment
+    tags:
+      - a
+      - 'b'
+    ids: [1, 2]
+    labels: {env: prod, tier: web}
+    skipped: ~
+url: http://example.com
+names:
+- Bob
+`
+
+		entries, err := config.ParseYAML([]byte(data))
+		assert.OK(t, err)
+		assert.Equal(t, []config.Entry{
+			{Key: "verbose", Values: []string{"true"}, Line: 3},
+			{Section: []string{"cluster", "list"}, Key: "format", Values: []string{"json"}, Line: 6},
+			{Section: []string{"cluster", "list"}, Key: "tags", Values: []string{"a", "b"}, Line: 7},
+			{Section: []string{"cluster", "list"}, Key: "ids", Values: []string{"1", "2"}, Line: 10},
+			{Section: []string{"cluster", "list", "labels"}, Key: "env", Values: []string{"prod"}, Line: 11},
+			{Section: []string{"cluster", "list", "labels"}, Key: "tier", Values: []string{"web"}, Line: 11},
+			{Key: "url", Values: []string{"http://example.com"}, Line: 13},
+			{Key: "names", Values: []string{"Bob"}, Line: 14},
+		}, entries)
+	})
+
+	t.Run("should report the line of unexpected indentation", func(t *testing.T) {
+		_, err := config.ParseYAML([]byte("greet:\n  name: Bob\n    count: 3\n"))
+		assert.NotOK(t, err)
+		assert.Equal(t, 3, err.(*config.Error).Line)
+	})
+
+	t.Run("should error for nested values in sequences", func(t *testing.T) {
+		_, err := config.ParseYAML([]byte("servers:\n  - name: a\n"))
+		assert.NotOK(t, err)
+		assert.Equal(t, "servers", err.(*config.Error).Key)
+	})
+
+	t.Run("should error for unsupported features", func(t *testing.T) {
+		_, err := config.ParseYAML([]byte("help: |\n  long\n"))
+		assert.NotOK(t, err)
+
+		_, err = config.ParseYAML([]byte("name: *alias\n"))
+		assert.NotOK(t, err)
+	})
+
+	t.Run("should error instead of reading unsupported documents incorrectly", func(t *testing.T) {
+		invalid := []string{
+			"help: >-\n  long\n",
+			"tags:\n  - |\n    long\n",
+			"base: &base\n  name: Bob\n",
+			"<<: *base\n",
+			"!!str name: Bob\n",
+			"? name\n: Bob\n",
+			"name: !!str Bob\n",
+			"labels: {env: {tier: web}}\n",
+			"ids: [1, [2, 3]]\n",
+			"tags: [a,\n  b]\n",
+			"name: Bob\n---\nname: Alice\n",
+			"%YAML 1.2\n---\nname: Bob\n",
+			"name: a: b\n",
+			"name: 'a' 'b'\n",
+		}
+
+		for _, data := range invalid {
+			_, err := config.ParseYAML([]byte(data))
+			assert.NotOK(t, err)
+		}
+	})
+}
@@ -0,0 +1,277 @@
+package console_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+	"github.com/seeruk/assert"
+)
+
+func TestApplicationConfig(t *testing.T) {
+	type values struct {
+		verbose bool
+		name    string
+		tags    []string
+		labels  map[string]string
+	}
+
+	createApplication := func(writer *bytes.Buffer, v *values) *console.Application {
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+		application.Writer = writer
+		application.ConfigName = "myapp"
+		application.ConfigEnvVar = "MYAPP_CONFIG"
+		application.AddGlobalOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&v.verbose),
+			Spec:  "-v, --verbose",
+		})
+
+		parent := &console.Command{Name: "cluster"}
+		parent.AddCommand(&console.Command{
+			Name: "list",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value:  parameters.NewStringValue(&v.name),
+					Spec:   "--name=NAME",
+					EnvVar: "NAME",
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringSliceValue(&v.tags),
+					Spec:  "--tag=TAG",
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringMapValue(&v.labels),
+					Spec:  "--label=LABEL",
+				})
+			},
+			Execute: func(input *console.Input, output *console.Output) error {
+				return nil
+			},
+		})
+
+		application.AddCommand(parent)
+
+		return application
+	}
+
+	writeFile := func(t *testing.T, path string, data string) {
+		assert.OK(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.OK(t, os.WriteFile(path, []byte(data), 0644))
+	}
+
+	dir, err := os.MkdirTemp("", "console-config")
+	assert.OK(t, err)
+	defer os.RemoveAll(dir)
+
+	t.Run("should read values from the command's section, and the top-level", func(t *testing.T) {
+		file := filepath.Join(dir, "sections.yaml")
+		writeFile(t, file, "verbose: true\ncluster:\n  list:\n    name: Bob\n    tag: [a, b]\n    label:\n      env: prod\n")
+
+		v := values{}
+		code := createApplication(&bytes.Buffer{}, &v).Run([]string{"cluster", "list", "--config", file}, []string{})
+
+		assert.Equal(t, 0, code)
+		assert.True(t, v.verbose, "Expected verbose to be set.")
+		assert.Equal(t, "Bob", v.name)
+		assert.Equal(t, []string{"a", "b"}, v.tags)
+		assert.Equal(t, map[string]string{"env": "prod"}, v.labels)
+	})
+
+	t.Run("should prefer input and the environment over config files", func(t *testing.T) {
+		file := filepath.Join(dir, "precedence.toml")
+		writeFile(t, file, "[cluster.list]\nname = \"Bob\"\ntag = [\"a\"]\n")
+
+		v := values{}
+		code := createApplication(&bytes.Buffer{}, &v).Run(
+			[]string{"cluster", "list", "--tag", "b"},
+			[]string{"MYAPP_CONFIG=" + file, "NAME=Alice"},
+		)
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "Alice", v.name)
+		assert.Equal(t, []string{"b"}, v.tags)
+	})
+
+	t.Run("should discover config files in XDG config directories", func(t *testing.T) {
+		writeFile(t, filepath.Join(dir, "system", "myapp", "config.ini"), "[cluster.list]\nname = Bob\ntag = a\n")
+		writeFile(t, filepath.Join(dir, "home", "myapp", "config.json"), `{"cluster": {"list": {"name": "Alice"}}}`)
+
+		v := values{}
+		code := createApplication(&bytes.Buffer{}, &v).Run([]string{"cluster", "list"}, []string{
+			"XDG_CONFIG_DIRS=" + filepath.Join(dir, "system"),
+			"XDG_CONFIG_HOME=" + filepath.Join(dir, "home"),
+		})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "Alice", v.name)
+		assert.Equal(t, []string{"a"}, v.tags)
+	})
+
+	t.Run("should only read values from the section of the command being run", func(t *testing.T) {
+		file := filepath.Join(dir, "commands.ini")
+		writeFile(t, file, "[greet]\nname = Bob\n\n[deploy]\nname = Alice\n")
+
+		var name string
+		var targets map[string]string
+
+		run := func(args ...string) int {
+			application := console.NewApplication("eidolon/console", "1.2.3+testing")
+			application.Writer = &bytes.Buffer{}
+			application.ConfigEnvVar = "MYAPP_CONFIG"
+
+			application.AddCommand(&console.Command{
+				Name: "greet",
+				Configure: func(definition *console.Definition) {
+					definition.AddOption(console.OptionDefinition{
+						Value: parameters.NewStringValue(&name),
+						Spec:  "--name=NAME",
+					})
+
+					definition.AddOption(console.OptionDefinition{
+						Value: parameters.NewStringMapValue(&targets),
+						Spec:  "--deploy=TARGET",
+					})
+				},
+				Execute: func(input *console.Input, output *console.Output) error {
+					return nil
+				},
+			})
+
+			application.AddCommand(&console.Command{
+				Name: "deploy",
+				Configure: func(definition *console.Definition) {
+					definition.AddOption(console.OptionDefinition{
+						Value: parameters.NewStringValue(&name),
+						Spec:  "--name=NAME",
+					})
+				},
+				Execute: func(input *console.Input, output *console.Output) error {
+					return nil
+				},
+			})
+
+			return application.Run(args, []string{"MYAPP_CONFIG=" + file})
+		}
+
+		code := run("greet")
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "Bob", name)
+		assert.Equal(t, 0, len(targets))
+
+		code = run("deploy")
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "Alice", name)
+	})
+
+	t.Run("should resolve sections of nested commands through the command tree", func(t *testing.T) {
+		file := filepath.Join(dir, "nested.toml")
+		writeFile(t, file, "[db]\nname = \"Bob\"\n\n[db.migrate]\nname = \"Alice\"\n")
+
+		var name string
+		var migrate map[string]string
+
+		run := func(args ...string) int {
+			application := console.NewApplication("eidolon/console", "1.2.3+testing")
+			application.Writer = &bytes.Buffer{}
+			application.ConfigEnvVar = "MYAPP_CONFIG"
+
+			db := &console.Command{
+				Name: "db",
+				Configure: func(definition *console.Definition) {
+					definition.AddOption(console.OptionDefinition{
+						Value: parameters.NewStringValue(&name),
+						Spec:  "--name=NAME",
+					})
+
+					definition.AddOption(console.OptionDefinition{
+						Value: parameters.NewStringMapValue(&migrate),
+						Spec:  "--migrate=SETTING",
+					})
+				},
+				Execute: func(input *console.Input, output *console.Output) error {
+					return nil
+				},
+			}
+
+			db.AddCommand(&console.Command{
+				Name: "migrate",
+				Configure: func(definition *console.Definition) {
+					definition.AddOption(console.OptionDefinition{
+						Value: parameters.NewStringValue(&name),
+						Spec:  "--name=NAME",
+					})
+				},
+				Execute: func(input *console.Input, output *console.Output) error {
+					return nil
+				},
+			})
+
+			application.AddCommand(db)
+
+			return application.Run(args, []string{"MYAPP_CONFIG=" + file})
+		}
+
+		code := run("db")
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "Bob", name)
+		assert.Equal(t, 0, len(migrate))
+
+		code = run("db", "migrate")
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "Alice", name)
+	})
+
+	t.Run("should report the file and line of parse errors", func(t *testing.T) {
+		file := filepath.Join(dir, "invalid.toml")
+		writeFile(t, file, "[cluster.list]\nname = Bob\n")
+
+		writer := bytes.Buffer{}
+		errWriter := bytes.Buffer{}
+
+		application := createApplication(&writer, &values{})
+		application.ErrorWriter = &errWriter
+
+		code := application.Run([]string{"cluster", "list", "--config", file}, []string{})
+
+		assert.Equal(t, 101, code)
+		assert.True(t, strings.Contains(errWriter.String(), file+":2: key 'cluster.list.name'"), "Expected location.")
+	})
+
+	t.Run("should report the file, line, and key of invalid values", func(t *testing.T) {
+		file := filepath.Join(dir, "invalid.yaml")
+		writeFile(t, file, "verbose: maybe\n")
+
+		writer := bytes.Buffer{}
+		errWriter := bytes.Buffer{}
+
+		application := createApplication(&writer, &values{})
+		application.ErrorWriter = &errWriter
+
+		code := application.Run([]string{"cluster", "list", "--config", file}, []string{})
+
+		assert.Equal(t, 101, code)
+		assert.True(t, strings.Contains(errWriter.String(), "for option 'verbose'"), "Expected key.")
+		assert.True(t, strings.Contains(errWriter.String(), "invalid.yaml' on line 1"), "Expected location.")
+	})
+
+	t.Run("should not add the config option unless config files are enabled", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		application := createApplication(&writer, &values{})
+		application.ConfigName = ""
+		application.ConfigEnvVar = ""
+		application.Run([]string{"cluster", "list", "--help"}, []string{})
+
+		assert.False(t, strings.Contains(writer.String(), "--config"), "Expected no config option.")
+	})
+}
@@ -2,6 +2,8 @@ package console
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/eidolon/console/parameters"
 	"github.com/eidolon/console/specification"
@@ -16,6 +18,19 @@ type Definition struct {
 	// Defined options for the current application run.
 	options   map[string]parameters.Option
 	optionSet []parameters.Option
+
+	// Defined constraints on which options may be given together.
+	constraints []parameters.OptionConstraint
+
+	// Where the values of arguments and options were set from when input was last mapped, by name.
+	sources map[string]valueSource
+
+	// Prefix used to derive environment variable names for options as they're added, if any.
+	envPrefix string
+
+	// Whether parameters are being added by an ancestor of the command being run, in which case only
+	// persistent options are kept.
+	inheriting bool
 }
 
 // NewDefinition creates a new Definition with sensible defaults.
@@ -23,6 +38,7 @@ func NewDefinition() *Definition {
 	definition := Definition{}
 	definition.arguments = make(map[string]parameters.Argument)
 	definition.options = make(map[string]parameters.Option)
+	definition.sources = make(map[string]valueSource)
 
 	return &definition
 }
@@ -35,6 +51,18 @@ type ArgumentDefinition struct {
 	Spec string
 	// The description of the argument.
 	Desc string
+	// The minimum number of values a variadic argument accepts, if any are given.
+	Min int
+	// The maximum number of values a variadic argument accepts, 0 meaning no limit.
+	Max int
+	// The default value of the argument, applied before input is mapped.
+	Default string
+	// Whether or not the argument is left out of contextual help and completion. Hidden arguments
+	// are still mapped as normal.
+	Hidden bool
+	// If set, the argument is deprecated, and this describes what to use instead, e.g. "use the
+	// --name option instead". A warning is shown when a deprecated argument is given.
+	Deprecated string
 }
 
 // OptionDefinition is a struct that represents the entire configuration of a CLI option.
@@ -47,6 +75,21 @@ type OptionDefinition struct {
 	Desc string
 	// The name of an environment variable to read an option value from.
 	EnvVar string
+	// The names of further environment variables to read an option value from, checked in order
+	// after EnvVar.
+	EnvVars []string
+	// Whether or not the option must be given, in the input, the environment, or a config file.
+	Required bool
+	// The default value of the option, applied before input is mapped.
+	Default string
+	// Whether or not the option is left out of contextual help and completion. Hidden options are
+	// still mapped as normal.
+	Hidden bool
+	// If set, the option is deprecated, and this describes what to use instead, e.g. "use --name
+	// instead". A warning is shown when a deprecated option is given.
+	Deprecated string
+	// Whether or not the option is inherited by every descendant of the command that declares it.
+	Persistent bool
 }
 
 // Arguments gets all of the arguments in this Definition.
@@ -54,7 +97,11 @@ func (d *Definition) Arguments() []parameters.Argument {
 	var arguments []parameters.Argument
 
 	for _, key := range d.argumentKeys {
-		arguments = append(arguments, d.arguments[key])
+		arg := d.arguments[key]
+		arg.Source = d.sources[arg.Name].source
+		arg.Origin = d.sources[arg.Name].origin
+
+		arguments = append(arguments, arg)
 	}
 
 	return arguments
@@ -62,41 +109,112 @@ func (d *Definition) Arguments() []parameters.Argument {
 
 // Options gets all of the options in this Definition.
 func (d *Definition) Options() []parameters.Option {
-	return d.optionSet
+	var options []parameters.Option
+
+	for _, opt := range d.optionSet {
+		opt.Source = d.sources[opt.Names[0]].source
+		opt.Origin = d.sources[opt.Names[0]].origin
+
+		options = append(options, opt)
+	}
+
+	return options
+}
+
+// Constraints gets all of the option constraints in this Definition.
+func (d *Definition) Constraints() []parameters.OptionConstraint {
+	return d.constraints
+}
+
+// IsSet reports whether the argument or option with the given name was explicitly given in the
+// input, the environment, or a config file when input was last mapped, rather than left at it's
+// default.
+func (d *Definition) IsSet(name string) bool {
+	return d.sources[strings.TrimLeft(name, "-")].given()
+}
+
+// Source reports where the value of the argument or option with the given name was set from when
+// input was last mapped, and exactly where, e.g. the name of an environment variable.
+func (d *Definition) Source(name string) (parameters.ValueSource, string) {
+	source := d.sources[strings.TrimLeft(name, "-")]
+
+	return source.source, source.origin
+}
+
+// optionValue gets the value of the option with the given name, or "" if there is no such option.
+func (d *Definition) optionValue(name string) string {
+	opt, ok := d.options[name]
+	if !ok {
+		return ""
+	}
+
+	return opt.Value.String()
 }
 
 // AddArgument creates a parameters.Argument and adds it to the Definition. Duplicate argument names
-// will result in an error.
+// will result in an error. Arguments aren't inherited, so are ignored when added by an ancestor of
+// the command being run.
 func (d *Definition) AddArgument(definition ArgumentDefinition) {
+	if d.inheriting {
+		return
+	}
+
 	arg, err := specification.ParseArgumentSpecification(definition.Spec)
 
 	if err != nil {
-		panic(fmt.Errorf("console: Error parsing argument specification: '%s'", err.Error()))
+		panic(fmt.Errorf("console: Error parsing argument specification: '%w'", err))
 	}
 
 	arg.Description = definition.Desc
 	arg.Value = definition.Value
+	arg.Min = definition.Min
+	arg.Max = definition.Max
+	arg.Default = definition.Default
+	arg.Hidden = definition.Hidden
+	arg.Deprecated = definition.Deprecated
 
 	if _, ok := d.arguments[arg.Name]; ok {
 		panic(fmt.Errorf("console: Cannot redeclare argument with name '%s'", arg.Name))
 	}
 
+	if len(d.argumentKeys) > 0 {
+		last := d.arguments[d.argumentKeys[len(d.argumentKeys)-1]]
+
+		if last.Variadic {
+			panic(fmt.Errorf("console: Cannot declare argument '%s' after variadic argument '%s'", arg.Name, last.Name))
+		}
+	}
+
 	d.arguments[arg.Name] = arg
 	d.argumentKeys = append(d.argumentKeys, arg.Name)
 }
 
 // AddOption creates a parameters.Option and adds it to the Definition. Duplicate option names will
-// result in an error.
+// result in an error. Options that aren't persistent are ignored when added by an ancestor of the
+// command being run.
 func (d *Definition) AddOption(definition OptionDefinition) {
+	if d.inheriting && !definition.Persistent {
+		return
+	}
+
 	opt, err := specification.ParseOptionSpecification(definition.Spec)
 
 	if err != nil {
-		panic(fmt.Errorf("console: Error parsing option specification: '%s'", err.Error()))
+		panic(fmt.Errorf("console: Error parsing option specification: '%w'", err))
 	}
 
 	opt.Description = definition.Desc
-	opt.EnvVar = definition.EnvVar
+	opt.EnvVars = deriveEnvVars(definition, opt, d.envPrefix)
 	opt.Value = definition.Value
+	opt.Required = definition.Required
+	opt.Default = definition.Default
+	opt.Hidden = definition.Hidden
+	opt.Deprecated = definition.Deprecated
+	opt.Inherited = d.inheriting
+
+	if len(opt.EnvVars) > 0 {
+		opt.EnvVar = opt.EnvVars[0]
+	}
 
 	for _, name := range opt.Names {
 		if _, ok := d.options[name]; ok {
@@ -109,3 +227,97 @@ func (d *Definition) AddOption(definition OptionDefinition) {
 
 	d.optionSet = append(d.optionSet, opt)
 }
+
+// deriveEnvVars creates the ordered list of environment variables to read an option's value from.
+// Explicitly named environment variables come first, followed by one derived from the given prefix
+// and the option's long name, if there is a prefix and the option has a long name.
+func deriveEnvVars(definition OptionDefinition, opt parameters.Option, prefix string) []string {
+	var envVars []string
+
+	if definition.EnvVar != "" {
+		envVars = append(envVars, definition.EnvVar)
+	}
+
+	envVars = append(envVars, definition.EnvVars...)
+
+	if prefix == "" {
+		return envVars
+	}
+
+	for _, name := range opt.Names {
+		if len(name) > 1 {
+			return append(envVars, envVarName(prefix, name))
+		}
+	}
+
+	return envVars
+}
+
+// envVarNameReplacer matches characters that aren't allowed in environment variable names.
+var envVarNameReplacer = regexp.MustCompile("[^A-Z0-9_]")
+
+// envVarName creates an environment variable name from the given parts, e.g. `MYAPP_GREET_NAME`.
+func envVarName(parts ...string) string {
+	name := strings.ToUpper(strings.Join(parts, "_"))
+
+	return envVarNameReplacer.ReplaceAllString(name, "_")
+}
+
+// MutuallyExclusive adds a constraint to the Definition that allows at most one of the options with
+// the given names to be given. Where they're given by sources with different precedence, e.g. one in
+// a config file and another in the input, the highest takes precedence and the others are ignored.
+// Undeclared option names will result in an error.
+func (d *Definition) MutuallyExclusive(names ...string) {
+	d.addConstraint(parameters.ConstraintMutuallyExclusive, names)
+}
+
+// RequiredTogether adds a constraint to the Definition that requires all of the options with the
+// given names to be given if any of them are. Undeclared option names will result in an error.
+func (d *Definition) RequiredTogether(names ...string) {
+	d.addConstraint(parameters.ConstraintRequiredTogether, names)
+}
+
+// AtLeastOne adds a constraint to the Definition that requires at least one of the options with the
+// given names to be given. Undeclared option names will result in an error.
+func (d *Definition) AtLeastOne(names ...string) {
+	d.addConstraint(parameters.ConstraintAtLeastOne, names)
+}
+
+// DependsOn adds a constraint to the Definition that requires all of the dependency options to be
+// given if the option with the given name is. Undeclared option names will result in an error.
+func (d *Definition) DependsOn(name string, dependencies ...string) {
+	d.addConstraint(parameters.ConstraintDependsOn, append([]string{name}, dependencies...))
+}
+
+// addConstraint creates a parameters.OptionConstraint and adds it to the Definition. Constraints
+// added by an ancestor of the command being run are only kept if all of their options are inherited.
+func (d *Definition) addConstraint(kind parameters.OptionConstraintKind, names []string) {
+	if len(names) < 2 {
+		panic(fmt.Errorf("console: Constraints must apply to at least 2 options, got %d", len(names)))
+	}
+
+	if d.inheriting {
+		for _, name := range names {
+			if _, ok := d.options[strings.TrimLeft(name, "-")]; !ok {
+				return
+			}
+		}
+	}
+
+	var trimmed []string
+
+	for _, name := range names {
+		name = strings.TrimLeft(name, "-")
+
+		if _, ok := d.options[name]; !ok {
+			panic(fmt.Errorf("console: Cannot constrain undeclared option '%s'", name))
+		}
+
+		trimmed = append(trimmed, name)
+	}
+
+	d.constraints = append(d.constraints, parameters.OptionConstraint{
+		Kind:  kind,
+		Names: trimmed,
+	})
+}
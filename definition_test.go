@@ -120,6 +120,27 @@ func TestDefinition(t *testing.T) {
 			})
 		})
 
+		t.Run("should error if an argument is added after a variadic argument", func(t *testing.T) {
+			defer func() {
+				r := recover()
+				assert.False(t, r == nil, "We should be recovering from a panic.")
+			}()
+
+			var ss1 []string
+			var s2 string
+
+			definition := console.NewDefinition()
+			definition.AddArgument(console.ArgumentDefinition{
+				Value: parameters.NewStringSliceValue(&ss1),
+				Spec:  "SS1...",
+			})
+
+			definition.AddArgument(console.ArgumentDefinition{
+				Value: parameters.NewStringValue(&s2),
+				Spec:  "S2",
+			})
+		})
+
 		t.Run("should add an argument", func(t *testing.T) {
 			var s1 string
 
@@ -185,5 +206,176 @@ func TestDefinition(t *testing.T) {
 
 			assert.Equal(t, 1, len(definition.Options()))
 		})
+
+		t.Run("should keep the first environment variable on the deprecated EnvVar field", func(t *testing.T) {
+			var s1 string
+
+			definition := console.NewDefinition()
+			definition.AddOption(console.OptionDefinition{
+				Value:   parameters.NewStringValue(&s1),
+				Spec:    "--s1=S1",
+				EnvVar:  "TEST_S1",
+				EnvVars: []string{"TEST_S1_LEGACY"},
+			})
+
+			opt := definition.Options()[0]
+			assert.Equal(t, []string{"TEST_S1", "TEST_S1_LEGACY"}, opt.EnvVars)
+			assert.Equal(t, "TEST_S1", opt.EnvVar)
+		})
+	})
+
+	t.Run("MutuallyExclusive()", func(t *testing.T) {
+		t.Run("should add a constraint", func(t *testing.T) {
+			var b1, b2 bool
+
+			definition := console.NewDefinition()
+			definition.AddOption(console.OptionDefinition{
+				Value: parameters.NewBoolValue(&b1),
+				Spec:  "-j, --json",
+			})
+
+			definition.AddOption(console.OptionDefinition{
+				Value: parameters.NewBoolValue(&b2),
+				Spec:  "--table",
+			})
+
+			definition.MutuallyExclusive("--json", "table")
+
+			constraints := definition.Constraints()
+
+			assert.Equal(t, 1, len(constraints))
+			assert.Equal(t, parameters.ConstraintMutuallyExclusive, constraints[0].Kind)
+			assert.Equal(t, []string{"json", "table"}, constraints[0].Names)
+		})
+
+		t.Run("should error if an option has not been declared", func(t *testing.T) {
+			defer func() {
+				r := recover()
+				assert.False(t, r == nil, "We should be recovering from a panic.")
+			}()
+
+			var b1 bool
+
+			definition := console.NewDefinition()
+			definition.AddOption(console.OptionDefinition{
+				Value: parameters.NewBoolValue(&b1),
+				Spec:  "--json",
+			})
+
+			definition.MutuallyExclusive("json", "table")
+		})
+
+		t.Run("should error if fewer than 2 options are given", func(t *testing.T) {
+			defer func() {
+				r := recover()
+				assert.False(t, r == nil, "We should be recovering from a panic.")
+			}()
+
+			var b1 bool
+
+			definition := console.NewDefinition()
+			definition.AddOption(console.OptionDefinition{
+				Value: parameters.NewBoolValue(&b1),
+				Spec:  "--json",
+			})
+
+			definition.MutuallyExclusive("json")
+		})
+	})
+
+	t.Run("DependsOn()", func(t *testing.T) {
+		t.Run("should add a constraint with the dependent option first", func(t *testing.T) {
+			var s1, s2 string
+
+			definition := console.NewDefinition()
+			definition.AddOption(console.OptionDefinition{
+				Value: parameters.NewStringValue(&s1),
+				Spec:  "--user=USER",
+			})
+
+			definition.AddOption(console.OptionDefinition{
+				Value: parameters.NewStringValue(&s2),
+				Spec:  "--password=PASSWORD",
+			})
+
+			definition.DependsOn("password", "user")
+
+			constraints := definition.Constraints()
+
+			assert.Equal(t, 1, len(constraints))
+			assert.Equal(t, parameters.ConstraintDependsOn, constraints[0].Kind)
+			assert.Equal(t, []string{"password", "user"}, constraints[0].Names)
+		})
+	})
+
+	t.Run("IsSet()", func(t *testing.T) {
+		t.Run("should report whether parameters were given when input was mapped", func(t *testing.T) {
+			var s1, s2, s3 string
+
+			definition := console.NewDefinition()
+			definition.AddArgument(console.ArgumentDefinition{
+				Value:   parameters.NewStringValue(&s1),
+				Spec:    "[S1]",
+				Default: "foo",
+			})
+
+			definition.AddOption(console.OptionDefinition{
+				Value:   parameters.NewStringValue(&s2),
+				Spec:    "-a, --s2=S2",
+				Default: "bar",
+			})
+
+			definition.AddOption(console.OptionDefinition{
+				Value:  parameters.NewStringValue(&s3),
+				Spec:   "--s3=S3",
+				EnvVar: "TEST_S3",
+			})
+
+			err := console.MapInput(definition, console.ParseInput([]string{"-a=baz"}), []string{
+				"TEST_S3=qux",
+			})
+
+			assert.OK(t, err)
+			assert.False(t, definition.IsSet("S1"), "Expected S1 to be left at it's default.")
+			assert.True(t, definition.IsSet("s2"), "Expected s2 to be set.")
+			assert.True(t, definition.IsSet("-a"), "Expected s2 to be set.")
+			assert.True(t, definition.IsSet("--s3"), "Expected s3 to be set.")
+		})
+	})
+
+	t.Run("Source()", func(t *testing.T) {
+		t.Run("should report where values were set from when input was mapped", func(t *testing.T) {
+			var s1, s2 string
+
+			definition := console.NewDefinition()
+			definition.AddOption(console.OptionDefinition{
+				Value:   parameters.NewStringValue(&s1),
+				Spec:    "--s1=S1",
+				Default: "foo",
+			})
+
+			definition.AddOption(console.OptionDefinition{
+				Value:  parameters.NewStringValue(&s2),
+				Spec:   "--s2=S2",
+				EnvVar: "TEST_S2",
+			})
+
+			err := console.MapInput(definition, console.ParseInput([]string{}), []string{
+				"TEST_S2=bar",
+			})
+
+			assert.OK(t, err)
+
+			source, origin := definition.Source("--s1")
+			assert.Equal(t, parameters.SourceDefault, source)
+			assert.Equal(t, "", origin)
+
+			source, origin = definition.Source("s2")
+			assert.Equal(t, parameters.SourceEnv, source)
+			assert.Equal(t, "TEST_S2", origin)
+
+			source, _ = definition.Source("s3")
+			assert.Equal(t, parameters.SourceNone, source)
+		})
 	})
 }
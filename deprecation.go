@@ -0,0 +1,70 @@
+package console
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eidolon/console/parameters"
+)
+
+// DeprecationWarning describes a deprecated command, argument, or option that was used.
+type DeprecationWarning struct {
+	// The kind of thing that is deprecated, i.e. "command", "argument", or "option".
+	Kind string
+	// The name of the command, argument, or option, as it would be given in input, e.g. `--name`.
+	Name string
+	// What to use instead, as given when the command, argument, or option was defined.
+	Message string
+}
+
+// String describes the deprecated command, argument, or option, and what to use instead.
+func (w DeprecationWarning) String() string {
+	return fmt.Sprintf("Warning: The %s '%s' is deprecated, %s", w.Kind, w.Name, w.Message)
+}
+
+// DeprecationFunc is a function to warn about a deprecated command, argument, or option being used.
+type DeprecationFunc func(output *Output, warning DeprecationWarning)
+
+// printDeprecationWarning is the default DeprecationFunc, which prints the warning to the output's
+// error writer.
+func printDeprecationWarning(output *Output, warning DeprecationWarning) {
+	output.Eprintln(output.escape(warning.String()))
+}
+
+// findDeprecations finds each deprecated command along the given path, and each deprecated argument
+// and option in the given definition that was given when input was last mapped.
+func (a *Application) findDeprecations(definition *Definition, path []string) []DeprecationWarning {
+	var warnings []DeprecationWarning
+
+	for i, cmd := range a.findCommandsOnPath(path) {
+		if cmd.Deprecated != "" {
+			warnings = append(warnings, DeprecationWarning{
+				Kind:    "command",
+				Name:    strings.Join(path[:i+1], " "),
+				Message: cmd.Deprecated,
+			})
+		}
+	}
+
+	for _, arg := range definition.Arguments() {
+		if arg.Deprecated != "" && arg.Source > parameters.SourceDefault {
+			warnings = append(warnings, DeprecationWarning{
+				Kind:    "argument",
+				Name:    arg.Name,
+				Message: arg.Deprecated,
+			})
+		}
+	}
+
+	for _, opt := range definition.Options() {
+		if opt.Deprecated != "" && opt.Source > parameters.SourceDefault {
+			warnings = append(warnings, DeprecationWarning{
+				Kind:    "option",
+				Name:    describeOptionName(opt),
+				Message: opt.Deprecated,
+			})
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,245 @@
+package console
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/eidolon/console/parameters"
+	"github.com/eidolon/console/style"
+)
+
+// docGenerators maps supported documentation formats to the functions that generate them.
+var docGenerators = map[string]func(app *Application, dir string) error{
+	"html":     GenerateHTMLDocs,
+	"man":      GenerateManPages,
+	"markdown": GenerateMarkdownDocs,
+}
+
+// docPage is the documentation for the application, or for one of it's commands. It is built from
+// the same data that contextual help is.
+type docPage struct {
+	// The full name of the page, e.g. `app cluster list`.
+	name string
+	// The path taken to reach the command, empty for the application.
+	path []string
+	// The single line description of the application or command.
+	description string
+	// The longer help message of the application or command.
+	help string
+	// How the application or command is run, e.g. `app greet [OPTIONS...] NAME`.
+	usage string
+	// What to use instead of the command, if it's deprecated.
+	deprecated string
+	// The visible arguments, options, inherited options, and sub-commands.
+	arguments        []docRow
+	options          []docRow
+	inheritedOptions []docRow
+	commands         []docRow
+	// The parent page, if this isn't the application's page, and the child pages.
+	parent   *docPage
+	children []*docPage
+}
+
+// docRow is a single named, and described, item in a documentation page section.
+type docRow struct {
+	name        string
+	description string
+	// The page the item links to, for sub-commands.
+	page *docPage
+}
+
+// fileName creates the base name of the files a page is written to, e.g. `app-cluster-list`.
+func (p *docPage) fileName() string {
+	return strings.Join(strings.Fields(p.name), "-")
+}
+
+// related gets the pages a page refers to, i.e. it's parent page, if any, and it's child pages.
+func (p *docPage) related() []*docPage {
+	var related []*docPage
+	if p.parent != nil {
+		related = append(related, p.parent)
+	}
+
+	return append(related, p.children...)
+}
+
+// GenerateDocs writes documentation for the application, and every visible command, to the given
+// directory, in the given format (html, man, or markdown). The directory is created if it doesn't
+// exist.
+func GenerateDocs(app *Application, format string, dir string) error {
+	generate, ok := docGenerators[format]
+	if !ok {
+		return fmt.Errorf("console: Unsupported documentation format '%s'", format)
+	}
+
+	return generate(app, dir)
+}
+
+// NewDocsCommand creates a hidden `docs FORMAT DIRECTORY` command that writes documentation for the
+// given application, e.g. for use with `go generate`. It still needs to be added to the
+// application.
+func NewDocsCommand(app *Application) *Command {
+	var format string
+	var dir string
+
+	var formats []string
+	for name := range docGenerators {
+		formats = append(formats, name)
+	}
+
+	sort.Strings(formats)
+
+	return &Command{
+		Name:        "docs",
+		Description: "Generate documentation.",
+		Hidden:      true,
+		Configure: func(definition *Definition) {
+			definition.AddArgument(ArgumentDefinition{
+				Value: parameters.NewEnumValue(&format, formats...),
+				Spec:  "FORMAT",
+				Desc:  fmt.Sprintf("The format to generate (%s).", strings.Join(formats, ", ")),
+			})
+
+			definition.AddArgument(ArgumentDefinition{
+				Value: parameters.NewStringValue(&dir),
+				Spec:  "DIRECTORY",
+				Desc:  "The directory to write documentation to.",
+			})
+		},
+		Execute: func(input *Input, output *Output) error {
+			return GenerateDocs(app, format, dir)
+		},
+	}
+}
+
+// buildDocPages builds the documentation page for the application, and for each visible command,
+// in tree order, with the application's page first.
+func buildDocPages(app *Application) []*docPage {
+	root := &docPage{
+		name:        app.UsageName,
+		description: app.Name,
+		help:        stripMarkup(app, app.Help),
+		usage:       describeApplicationUsageLine(app),
+		options:     describeDocOptions(app, visibleOptions(findApplicationOptions(app))),
+	}
+
+	pages := []*docPage{root}
+
+	var loop func(parent *docPage, commands []*Command)
+
+	loop = func(parent *docPage, commands []*Command) {
+		for _, cmd := range sortCommands(visibleCommands(commands)) {
+			page := buildCommandDocPage(app, cmd, append(append([]string{}, parent.path...), cmd.Name))
+			page.parent = parent
+
+			parent.children = append(parent.children, page)
+			parent.commands = append(parent.commands, docRow{
+				name:        cmd.Name,
+				description: stripMarkup(app, describeCommandSummary(cmd)),
+				page:        page,
+			})
+
+			pages = append(pages, page)
+
+			loop(page, cmd.commands)
+		}
+	}
+
+	loop(root, app.commands)
+
+	return pages
+}
+
+// buildCommandDocPage builds the documentation page for a command, without it's sub-commands.
+func buildCommandDocPage(app *Application, cmd *Command, path []string) *docPage {
+	definition := buildCommandDefinition(app, cmd)
+
+	arguments := visibleArguments(definition.Arguments())
+	options := visibleOptions(definition.Options())
+	ownOptions, inheritedOptions := splitInheritedOptions(options)
+
+	page := &docPage{
+		name:             strings.Join(append([]string{app.UsageName}, path...), " "),
+		path:             path,
+		description:      stripMarkup(app, cmd.Description),
+		help:             stripMarkup(app, cmd.Help),
+		usage:            describeCommandUsageLine(app, arguments, options, path),
+		deprecated:       stripMarkup(app, cmd.Deprecated),
+		options:          describeDocOptions(app, ownOptions),
+		inheritedOptions: describeDocOptions(app, inheritedOptions),
+	}
+
+	for _, arg := range arguments {
+		name, description := parameters.DescribeArgument(arg)
+		page.arguments = append(page.arguments, docRow{name: name, description: stripMarkup(app, description)})
+	}
+
+	sort.SliceStable(page.arguments, func(i, j int) bool {
+		return page.arguments[i].name < page.arguments[j].name
+	})
+
+	return page
+}
+
+// describeDocOptions describes options as documentation rows, in the same order as help output.
+func describeDocOptions(app *Application, options []parameters.Option) []docRow {
+	var rows []docRow
+
+	for _, opt := range options {
+		name, description := parameters.DescribeOption(opt)
+		rows = append(rows, docRow{name: name, description: stripMarkup(app, description)})
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return strings.TrimLeft(rows[i].name, "-") < strings.TrimLeft(rows[j].name, "-")
+	})
+
+	return rows
+}
+
+// sortCommands creates a copy of the given commands, sorted by name.
+func sortCommands(commands []*Command) []*Command {
+	sorted := append([]*Command{}, commands...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	return sorted
+}
+
+// writeDocPages renders each page with the given function, and writes it to the given directory,
+// named after the page, with the given extension.
+func writeDocPages(pages []*docPage, dir string, ext string, render func(page *docPage) string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, page := range pages {
+		path := filepath.Join(dir, page.fileName()+ext)
+
+		if err := os.WriteFile(path, []byte(render(page)), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// stripMarkup strips the styles, and links, that are known to the given application from text,
+// as documentation is never shown in a terminal. Text is left as it is if markup isn't enabled.
+func stripMarkup(app *Application, text string) string {
+	if !app.Markup {
+		return text
+	}
+
+	renderer := app.styles
+	if renderer == nil {
+		renderer = style.NewRenderer()
+	}
+
+	return renderer.Render(text, false)
+}
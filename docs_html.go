@@ -0,0 +1,160 @@
+package console
+
+import (
+	"bytes"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GenerateHTMLDocs writes a static HTML reference for the application and every visible command to
+// an `index.html` file in the given directory. Each command has it's own section, linked to from a
+// table of contents, and from it's parent's list of commands.
+func GenerateHTMLDocs(app *Application, dir string) error {
+	pages := buildDocPages(app)
+
+	var sections []htmlDocSection
+	for _, page := range pages {
+		sections = append(sections, newHTMLDocSection(page))
+	}
+
+	var buf bytes.Buffer
+
+	err := htmlDocsTemplate.Execute(&buf, map[string]interface{}{
+		"Title":    strings.TrimSpace(app.Name + " " + app.Version),
+		"Sections": sections,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, "index.html"), buf.Bytes(), 0644)
+}
+
+// htmlDocSection is the data used to render a documentation page as a section of the HTML
+// reference. Templates can only use exported fields.
+type htmlDocSection struct {
+	ID          string
+	Name        string
+	Description string
+	Help        []string
+	Usage       string
+	Deprecated  string
+	Tables      []htmlDocTable
+}
+
+// htmlDocTable is a titled table of named, and described, items in a section of the HTML reference.
+type htmlDocTable struct {
+	Title string
+	Rows  []htmlDocRow
+}
+
+// htmlDocRow is a single named, and described, item in a section of the HTML reference.
+type htmlDocRow struct {
+	Name        string
+	Description string
+	// The ID of the section the item links to, if any.
+	Link string
+}
+
+// newHTMLDocSection creates the section of the HTML reference for a documentation page.
+func newHTMLDocSection(page *docPage) htmlDocSection {
+	section := htmlDocSection{
+		ID:          page.fileName(),
+		Name:        page.name,
+		Description: page.description,
+		Usage:       page.usage,
+		Deprecated:  page.deprecated,
+	}
+
+	tables := []htmlDocTable{
+		{"Arguments", newHTMLDocRows(page.arguments)},
+		{"Options", newHTMLDocRows(page.options)},
+		{"Inherited options", newHTMLDocRows(page.inheritedOptions)},
+		{"Commands", newHTMLDocRows(page.commands)},
+	}
+
+	for _, table := range tables {
+		if len(table.Rows) > 0 {
+			section.Tables = append(section.Tables, table)
+		}
+	}
+
+	if help := strings.TrimSpace(page.help); help != "" {
+		section.Help = strings.Split(help, "\n\n")
+	}
+
+	return section
+}
+
+// newHTMLDocRows creates rows of the HTML reference from documentation rows.
+func newHTMLDocRows(rows []docRow) []htmlDocRow {
+	var htmlRows []htmlDocRow
+
+	for _, row := range rows {
+		htmlRow := htmlDocRow{Name: row.name, Description: row.description}
+		if row.page != nil {
+			htmlRow.Link = row.page.fileName()
+		}
+
+		htmlRows = append(htmlRows, htmlRow)
+	}
+
+	return htmlRows
+}
+
+var htmlDocsTemplate = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 960px; margin: 0 auto; padding: 1em; }
+pre, code { font-family: monospace; }
+pre { background: #f4f4f4; padding: 0.5em; }
+table { border-collapse: collapse; }
+td { padding: 0.25em 1em 0.25em 0; vertical-align: top; }
+.deprecated { color: #a00; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<nav>
+<ul>
+{{- range .Sections}}
+<li><a href="#{{.ID}}">{{.Name}}</a></li>
+{{- end}}
+</ul>
+</nav>
+{{- range .Sections}}
+<section id="{{.ID}}">
+<h2>{{.Name}}</h2>
+{{- if .Description}}
+<p>{{.Description}}</p>
+{{- end}}
+{{- if .Deprecated}}
+<p class="deprecated"><strong>Deprecated:</strong> {{.Deprecated}}</p>
+{{- end}}
+<pre><code>{{.Usage}}</code></pre>
+{{- range .Help}}
+<p>{{.}}</p>
+{{- end}}
+{{- range .Tables}}
+<h3>{{.Title}}</h3>
+<table>
+{{- range .Rows}}
+<tr><td><code>{{if .Link}}<a href="#{{.Link}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}</code></td><td>{{.Description}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</section>
+{{- end}}
+</body>
+</html>
+`))
@@ -0,0 +1,105 @@
+package console
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GenerateManPages writes a roff man page, in section 1, for the application and for every visible
+// command to the given directory, e.g. `app.1`, and `app-cluster-list.1`. Each page refers to the
+// pages of it's parent and sub-commands.
+func GenerateManPages(app *Application, dir string) error {
+	return writeDocPages(buildDocPages(app), dir, ".1", func(page *docPage) string {
+		return renderManPage(app, page)
+	})
+}
+
+// renderManPage renders a single documentation page as a roff man page.
+func renderManPage(app *Application, page *docPage) string {
+	var man strings.Builder
+
+	fmt.Fprintf(
+		&man,
+		".TH \"%s\" \"1\" \"\" \"%s\" \"\"\n",
+		manEscape(strings.ToUpper(page.fileName())),
+		manEscape(strings.TrimSpace(app.Name+" "+app.Version)),
+	)
+
+	man.WriteString(".SH NAME\n")
+	if page.description != "" {
+		fmt.Fprintf(&man, "%s \\- %s\n", manEscape(page.fileName()), manEscape(page.description))
+	} else {
+		fmt.Fprintf(&man, "%s\n", manEscape(page.fileName()))
+	}
+
+	man.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(&man, ".B %s\n", manEscape(page.usage))
+
+	if page.help != "" {
+		man.WriteString(".SH DESCRIPTION\n")
+		man.WriteString(manParagraphs(page.help))
+	}
+
+	if page.deprecated != "" {
+		man.WriteString(".SH DEPRECATED\n")
+		man.WriteString(manParagraphs(page.deprecated))
+	}
+
+	man.WriteString(manSection("ARGUMENTS", page.arguments))
+	man.WriteString(manSection("OPTIONS", page.options))
+	man.WriteString(manSection("INHERITED OPTIONS", page.inheritedOptions))
+	man.WriteString(manSection("COMMANDS", page.commands))
+
+	if related := page.related(); len(related) > 0 {
+		var refs []string
+		for _, other := range related {
+			refs = append(refs, fmt.Sprintf("\\fB%s\\fP(1)", manEscape(other.fileName())))
+		}
+
+		man.WriteString(".SH SEE ALSO\n")
+		man.WriteString(strings.Join(refs, ", ") + "\n")
+	}
+
+	return man.String()
+}
+
+// manSection renders a titled section of named, and described, items. Empty sections are omitted.
+func manSection(title string, rows []docRow) string {
+	if len(rows) == 0 {
+		return ""
+	}
+
+	section := fmt.Sprintf(".SH %s\n", title)
+
+	for _, row := range rows {
+		section += fmt.Sprintf(".TP\n\\fB%s\\fP\n%s\n", manEscape(row.name), manEscape(row.description))
+	}
+
+	return section
+}
+
+// manParagraphs renders text as roff paragraphs, separated by blank lines in the text.
+func manParagraphs(text string) string {
+	var paragraphs []string
+
+	for _, paragraph := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		paragraphs = append(paragraphs, manEscape(strings.TrimSpace(paragraph)))
+	}
+
+	return strings.Join(paragraphs, "\n.PP\n") + "\n"
+}
+
+// manEscape escapes text so that it is shown as-is in a roff document.
+func manEscape(text string) string {
+	text = strings.NewReplacer("\\", "\\e", "-", "\\-").Replace(text)
+
+	// Lines starting with a control character would be read as requests.
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = "\\&" + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
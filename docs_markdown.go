@@ -0,0 +1,83 @@
+package console
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GenerateMarkdownDocs writes a Markdown page for the application and for every visible command to
+// the given directory, e.g. `app.md`, and `app-cluster-list.md`. Pages link to the pages of their
+// parent and sub-commands.
+func GenerateMarkdownDocs(app *Application, dir string) error {
+	return writeDocPages(buildDocPages(app), dir, ".md", renderMarkdownPage)
+}
+
+// renderMarkdownPage renders a single documentation page as Markdown.
+func renderMarkdownPage(page *docPage) string {
+	var md strings.Builder
+
+	fmt.Fprintf(&md, "# %s\n\n", page.name)
+
+	if page.description != "" {
+		fmt.Fprintf(&md, "%s\n\n", page.description)
+	}
+
+	if page.deprecated != "" {
+		fmt.Fprintf(&md, "> **Deprecated:** %s\n\n", page.deprecated)
+	}
+
+	fmt.Fprintf(&md, "## Usage\n\n```\n%s\n```\n\n", page.usage)
+
+	if page.help != "" {
+		fmt.Fprintf(&md, "%s\n\n", strings.TrimSpace(page.help))
+	}
+
+	md.WriteString(markdownSection("Arguments", "Argument", page.arguments))
+	md.WriteString(markdownSection("Options", "Option", page.options))
+	md.WriteString(markdownSection("Inherited options", "Option", page.inheritedOptions))
+	md.WriteString(markdownSection("Commands", "Command", page.commands))
+
+	if related := page.related(); len(related) > 0 {
+		md.WriteString("## See also\n\n")
+
+		for _, other := range related {
+			fmt.Fprintf(&md, "* [%s](%s.md)", other.name, other.fileName())
+
+			if other.description != "" {
+				fmt.Fprintf(&md, " - %s", other.description)
+			}
+
+			md.WriteString("\n")
+		}
+
+		md.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(md.String(), "\n")
+}
+
+// markdownSection renders a titled table of named, and described, items. Items that link to another
+// page have their name linked. Empty sections are omitted.
+func markdownSection(title string, heading string, rows []docRow) string {
+	if len(rows) == 0 {
+		return ""
+	}
+
+	section := fmt.Sprintf("## %s\n\n| %s | Description |\n| --- | --- |\n", title, heading)
+
+	for _, row := range rows {
+		name := fmt.Sprintf("`%s`", row.name)
+		if row.page != nil {
+			name = fmt.Sprintf("[%s](%s.md)", name, row.page.fileName())
+		}
+
+		section += fmt.Sprintf("| %s | %s |\n", name, markdownEscapeCell(row.description))
+	}
+
+	return section + "\n"
+}
+
+// markdownEscapeCell escapes text so that it can be used in a single table cell.
+func markdownEscapeCell(text string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(text)
+}
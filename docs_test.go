@@ -0,0 +1,181 @@
+package console_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+	"github.com/seeruk/assert"
+)
+
+func TestGenerateDocs(t *testing.T) {
+	createApplication := func() *console.Application {
+		var value string
+
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+		application.UsageName = "app"
+		application.Markup = true
+		application.Help = "Manage <clusters> with ease."
+
+		cluster := &console.Command{
+			Name:        "cluster",
+			Description: "Manage clusters.",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value:      parameters.NewStringValue(&value),
+					Spec:       "--context=CONTEXT",
+					Desc:       "The context to use.",
+					Persistent: true,
+				})
+			},
+		}
+
+		cluster.AddCommand(&console.Command{
+			Name:        "list",
+			Description: "List clusters.",
+			Help:        "Lists every cluster <b>you</b> can see.",
+			Configure: func(definition *console.Definition) {
+				definition.AddArgument(console.ArgumentDefinition{
+					Value: parameters.NewStringValue(&value),
+					Spec:  "[FILTER]",
+					Desc:  "Only list matching clusters.",
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringValue(&value),
+					Spec:  "-f, --format=FORMAT",
+					Desc:  "Output format.",
+				})
+			},
+			Execute: func(input *console.Input, output *console.Output) error {
+				return nil
+			},
+		})
+
+		application.AddCommand(cluster)
+		application.AddCommand(&console.Command{Name: "experiment", Hidden: true})
+		application.AddCommand(console.NewDocsCommand(application))
+
+		return application
+	}
+
+	readDir := func(t *testing.T, dir string) map[string]string {
+		entries, err := os.ReadDir(dir)
+		assert.OK(t, err)
+
+		files := make(map[string]string)
+		for _, entry := range entries {
+			content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+			assert.OK(t, err)
+
+			files[entry.Name()] = string(content)
+		}
+
+		return files
+	}
+
+	fileNames := func(files map[string]string) []string {
+		var names []string
+		for name := range files {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		return names
+	}
+
+	t.Run("should write a man page for each visible command", func(t *testing.T) {
+		dir := t.TempDir()
+
+		err := console.GenerateManPages(createApplication(), dir)
+		assert.OK(t, err)
+
+		files := readDir(t, dir)
+		assert.Equal(t, []string{"app-cluster-list.1", "app-cluster.1", "app.1"}, fileNames(files))
+
+		page := files["app-cluster-list.1"]
+		assert.True(t, strings.HasPrefix(page, ".TH \"APP\\-CLUSTER\\-LIST\" \"1\""), "Expected title.")
+		assert.True(t, strings.Contains(page, "app\\-cluster\\-list \\- List clusters."), "Expected name.")
+		assert.True(t, strings.Contains(page, ".B app cluster list [OPTIONS...] [FILTER]"), "Expected synopsis.")
+		assert.True(t, strings.Contains(page, "\\fB\\-f, \\-\\-format=FORMAT\\fP\nOutput format."), "Expected option.")
+		assert.True(t, strings.Contains(page, ".SH INHERITED OPTIONS\n.TP\n\\fB\\-\\-context=CONTEXT\\fP"), "Expected inherited option.")
+		assert.True(t, strings.Contains(page, ".SH SEE ALSO\n\\fBapp\\-cluster\\fP(1)\n"), "Expected see also.")
+
+		page = files["app-cluster.1"]
+		assert.True(t, strings.Contains(page, "\\fBapp\\fP(1), \\fBapp\\-cluster\\-list\\fP(1)"), "Expected see also.")
+	})
+
+	t.Run("should write a Markdown page for each visible command", func(t *testing.T) {
+		dir := t.TempDir()
+
+		err := console.GenerateMarkdownDocs(createApplication(), dir)
+		assert.OK(t, err)
+
+		files := readDir(t, dir)
+		assert.Equal(t, []string{"app-cluster-list.md", "app-cluster.md", "app.md"}, fileNames(files))
+
+		page := files["app-cluster-list.md"]
+		assert.True(t, strings.HasPrefix(page, "# app cluster list\n\nList clusters.\n"), "Expected title.")
+		assert.True(t, strings.Contains(page, "```\napp cluster list [OPTIONS...] [FILTER]\n```"), "Expected usage.")
+		assert.True(t, strings.Contains(page, "| `FILTER` | Only list matching clusters. |"), "Expected argument.")
+		assert.True(t, strings.Contains(page, "Lists every cluster you can see."), "Expected help without markup.")
+		assert.True(t, strings.Contains(page, "## Inherited options"), "Expected inherited options.")
+		assert.True(t, strings.Contains(page, "* [app cluster](app-cluster.md) - Manage clusters."), "Expected see also.")
+
+		page = files["app.md"]
+		assert.True(t, strings.Contains(page, "| [`cluster`](app-cluster.md) | Manage clusters. |"), "Expected command link.")
+		assert.False(t, strings.Contains(page, "experiment"), "Expected no hidden command.")
+		assert.False(t, strings.Contains(page, "docs"), "Expected no docs command.")
+	})
+
+	t.Run("should write a static HTML reference", func(t *testing.T) {
+		dir := t.TempDir()
+
+		err := console.GenerateHTMLDocs(createApplication(), dir)
+		assert.OK(t, err)
+
+		files := readDir(t, dir)
+		assert.Equal(t, []string{"index.html"}, fileNames(files))
+
+		page := files["index.html"]
+		assert.True(t, strings.Contains(page, `<section id="app-cluster-list">`), "Expected section.")
+		assert.True(t, strings.Contains(page, `<a href="#app-cluster">cluster</a>`), "Expected command link.")
+		assert.True(t, strings.Contains(page, "Manage &lt;clusters&gt; with ease."), "Expected escaped help.")
+		assert.False(t, strings.Contains(page, "experiment"), "Expected no hidden command.")
+	})
+
+	t.Run("should error for unsupported formats", func(t *testing.T) {
+		err := console.GenerateDocs(createApplication(), "pdf", t.TempDir())
+		assert.NotOK(t, err)
+	})
+}
+
+func TestNewDocsCommand(t *testing.T) {
+	t.Run("should write documentation in the given format", func(t *testing.T) {
+		dir := t.TempDir()
+		writer := bytes.Buffer{}
+
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+		application.UsageName = "app"
+		application.Writer = &writer
+		application.AddCommand(console.NewDocsCommand(application))
+
+		code := application.Run([]string{"docs", "man", dir}, []string{})
+		assert.Equal(t, 0, code)
+
+		_, err := os.Stat(filepath.Join(dir, "app.1"))
+		assert.OK(t, err)
+	})
+
+	t.Run("should be hidden", func(t *testing.T) {
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+
+		assert.True(t, console.NewDocsCommand(application).Hidden, "Expected hidden command.")
+	})
+}
@@ -0,0 +1,175 @@
+package console
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eidolon/console/parameters"
+)
+
+// MissingArgumentError is returned when a required argument isn't given, or when a variadic
+// argument is given fewer values than it's minimum.
+type MissingArgumentError struct {
+	// The name of the argument.
+	Name string
+	// The minimum number of values the argument accepts, for variadic arguments.
+	Min int
+	// The raw values that were given, if any.
+	Values []string
+}
+
+// Error describes the missing argument.
+func (e *MissingArgumentError) Error() string {
+	if len(e.Values) > 0 {
+		return fmt.Sprintf("console: Argument '%s' requires at least %d value(s)", e.Name, e.Min)
+	}
+
+	return fmt.Sprintf("console: Argument '%s' is required", e.Name)
+}
+
+// TooManyArgumentsError is returned when more arguments are given than are accepted, either by a
+// variadic argument, or by the command as a whole.
+type TooManyArgumentsError struct {
+	// The name of the variadic argument given too many values, if there is one.
+	Name string
+	// The maximum number of values the argument accepts, for variadic arguments.
+	Max int
+	// The raw values that were given. For a command as a whole, only the unexpected values.
+	Values []string
+}
+
+// Error describes the surplus arguments.
+func (e *TooManyArgumentsError) Error() string {
+	if e.Name != "" {
+		return fmt.Sprintf("console: Argument '%s' accepts at most %d value(s)", e.Name, e.Max)
+	}
+
+	return fmt.Sprintf("console: Too many arguments, unexpected '%s'", strings.Join(e.Values, "', '"))
+}
+
+// MissingOptionError is returned when a required option isn't given.
+type MissingOptionError struct {
+	// The name of the option, with it's leading hyphens, e.g. `--name`.
+	Name string
+	// The environment variables the option may be given by instead, if any.
+	EnvVars []string
+}
+
+// Error describes the missing option.
+func (e *MissingOptionError) Error() string {
+	if len(e.EnvVars) > 0 {
+		return fmt.Sprintf(
+			"console: Option '%s' is required, or environment variable '%s' must be set",
+			e.Name,
+			strings.Join(e.EnvVars, "' or '"),
+		)
+	}
+
+	return fmt.Sprintf("console: Option '%s' is required", e.Name)
+}
+
+// MissingOptionValueError is returned when an option that requires a value is given without one.
+type MissingOptionValueError struct {
+	// The name the option was given by; an option name, an environment variable, or a config key.
+	Name string
+	// Where the option was given.
+	Source parameters.ValueSource
+}
+
+// Error describes the option that's missing it's value.
+func (e *MissingOptionValueError) Error() string {
+	return fmt.Sprintf("console: Option '%s' requires a value", e.Name)
+}
+
+// InvalidValueError is returned when a value can't be set on an argument or option. The error
+// returned by the parameters.Value is wrapped.
+type InvalidValueError struct {
+	// The kind of parameter the value was for, either "argument", or "option".
+	Parameter string
+	// The name the parameter was given by; an argument name, an option name, an environment
+	// variable, or a config key.
+	Name string
+	// The raw value that was given.
+	Value string
+	// Where the value was given.
+	Source parameters.ValueSource
+	// The error returned when setting the value.
+	Err error
+}
+
+// Error describes the invalid value, and why it's invalid.
+func (e *InvalidValueError) Error() string {
+	if e.Source == parameters.SourceDefault {
+		return fmt.Sprintf("console: Invalid default value '%s' for %s '%s'. Error: %s", e.Value, e.Parameter, e.Name, e.Err)
+	}
+
+	return fmt.Sprintf("console: Invalid value '%s' for %s '%s'. Error: %s", e.Value, e.Parameter, e.Name, e.Err)
+}
+
+// Unwrap gets the error returned when setting the value.
+func (e *InvalidValueError) Unwrap() error {
+	return e.Err
+}
+
+// ConstraintError is returned when the options given don't satisfy a constraint on the definition,
+// e.g. mutually exclusive options are given together. The error returned by the constraint, which
+// names the offending options, is wrapped.
+type ConstraintError struct {
+	// The kind of constraint that wasn't satisfied.
+	Kind parameters.OptionConstraintKind
+	// The names of the options the constraint applies to, without leading hyphens.
+	Names []string
+	// The error returned when checking the constraint.
+	Err error
+}
+
+// Error describes the constraint that wasn't satisfied.
+func (e *ConstraintError) Error() string {
+	return fmt.Sprintf("console: %s", e.Err)
+}
+
+// Unwrap gets the error returned when checking the constraint.
+func (e *ConstraintError) Unwrap() error {
+	return e.Err
+}
+
+// UnknownOptionError is returned when an option is given that isn't defined.
+type UnknownOptionError struct {
+	// The name of the option, as it was given, e.g. `--nmae`.
+	Name string
+	// Defined options with similar names, e.g. `--name`.
+	Suggestions []string
+}
+
+// Error describes the unknown option, and suggests what may have been meant.
+func (e *UnknownOptionError) Error() string {
+	return fmt.Sprintf("console: Unknown option '%s'%s", e.Name, describeSuggestions(e.Suggestions))
+}
+
+// UnknownCommandError is returned when a command is asked for that doesn't exist.
+type UnknownCommandError struct {
+	// The name of the command, as it was given.
+	Name string
+	// The path of the command that was searched for a sub-command, empty for the application.
+	Path []string
+	// Names and aliases of similarly named commands at the same depth, e.g. `greet`.
+	Suggestions []string
+}
+
+// Error describes the unknown command, and suggests what may have been meant.
+func (e *UnknownCommandError) Error() string {
+	return fmt.Sprintf(
+		"console: Unknown command '%s'%s",
+		strings.Join(append(append([]string{}, e.Path...), e.Name), " "),
+		describeSuggestions(e.Suggestions),
+	)
+}
+
+// describeSuggestions creates a "did you mean" message for the given suggestions, if there are any.
+func describeSuggestions(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(", did you mean '%s'?", strings.Join(suggestions, "' or '"))
+}
@@ -0,0 +1,205 @@
+package console_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+	"github.com/eidolon/console/specification"
+	"github.com/seeruk/assert"
+)
+
+func TestMappingErrorTypes(t *testing.T) {
+	t.Run("should return a MissingArgumentError for missing arguments", func(t *testing.T) {
+		var s1 string
+
+		definition := console.NewDefinition()
+		definition.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewStringValue(&s1),
+			Spec:  "S1",
+		})
+
+		err := console.MapInput(definition, console.ParseInput([]string{}), []string{})
+
+		var target *console.MissingArgumentError
+		assert.True(t, errors.As(err, &target), "Expected a *console.MissingArgumentError.")
+		assert.Equal(t, "S1", target.Name)
+	})
+
+	t.Run("should return a TooManyArgumentsError for variadic arguments given too many values", func(t *testing.T) {
+		var ss1 []string
+
+		definition := console.NewDefinition()
+		definition.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewStringSliceValue(&ss1),
+			Spec:  "S1...",
+			Max:   1,
+		})
+
+		err := console.MapInput(definition, console.ParseInput([]string{"foo", "bar"}), []string{})
+
+		var target *console.TooManyArgumentsError
+		assert.True(t, errors.As(err, &target), "Expected a *console.TooManyArgumentsError.")
+		assert.Equal(t, "S1", target.Name)
+		assert.Equal(t, []string{"foo", "bar"}, target.Values)
+	})
+
+	t.Run("should return a MissingOptionValueError for options missing values", func(t *testing.T) {
+		var s1 string
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value:  parameters.NewStringValue(&s1),
+			Spec:   "--s1=S1",
+			EnvVar: "TEST_S1",
+		})
+
+		err := console.MapInput(definition, console.ParseInput([]string{}), []string{"TEST_S1="})
+
+		var target *console.MissingOptionValueError
+		assert.True(t, errors.As(err, &target), "Expected a *console.MissingOptionValueError.")
+		assert.Equal(t, "TEST_S1", target.Name)
+		assert.Equal(t, parameters.SourceEnv, target.Source)
+	})
+
+	t.Run("should return a MissingOptionError for missing required options", func(t *testing.T) {
+		var s1 string
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value:    parameters.NewStringValue(&s1),
+			Spec:     "--s1=S1",
+			Required: true,
+		})
+
+		err := console.MapInput(definition, console.ParseInput([]string{}), []string{})
+
+		var target *console.MissingOptionError
+		assert.True(t, errors.As(err, &target), "Expected a *console.MissingOptionError.")
+		assert.Equal(t, "--s1", target.Name)
+	})
+
+	t.Run("should return an InvalidValueError wrapping the value's error", func(t *testing.T) {
+		var i1 int
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewIntValue(&i1),
+			Spec:  "--i1=I1",
+		})
+
+		err := console.MapInput(definition, console.ParseInput([]string{"--i1=one"}), []string{})
+
+		var target *console.InvalidValueError
+		assert.True(t, errors.As(err, &target), "Expected a *console.InvalidValueError.")
+		assert.Equal(t, "option", target.Parameter)
+		assert.Equal(t, "i1", target.Name)
+		assert.Equal(t, "one", target.Value)
+		assert.Equal(t, parameters.SourceInput, target.Source)
+
+		var numErr *strconv.NumError
+		assert.True(t, errors.As(err, &numErr), "Expected the value's error to be wrapped.")
+	})
+
+	t.Run("should return a ConstraintError for options that don't satisfy a constraint", func(t *testing.T) {
+		var b1, b2 bool
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&b1),
+			Spec:  "--b1",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&b2),
+			Spec:  "--b2",
+		})
+
+		definition.MutuallyExclusive("b1", "b2")
+
+		err := console.MapInput2(definition, console.ParseInput([]string{"--b1", "--b2"}), []string{})
+
+		var target *console.ConstraintError
+		assert.True(t, errors.As(err, &target), "Expected a *console.ConstraintError.")
+		assert.Equal(t, parameters.ConstraintMutuallyExclusive, target.Kind)
+		assert.Equal(t, []string{"b1", "b2"}, target.Names)
+	})
+
+	t.Run("should find errors among every problem from MapInput2", func(t *testing.T) {
+		var s1 string
+		var i1 int
+
+		definition := console.NewDefinition()
+		definition.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewStringValue(&s1),
+			Spec:  "S1",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewIntValue(&i1),
+			Spec:  "--i1=I1",
+		})
+
+		err := console.MapInput2(definition, console.ParseInput([]string{"--i1=one"}), []string{})
+
+		var missing *console.MissingArgumentError
+		assert.True(t, errors.As(err, &missing), "Expected a *console.MissingArgumentError.")
+
+		var invalid *console.InvalidValueError
+		assert.True(t, errors.As(err, &invalid), "Expected a *console.InvalidValueError.")
+	})
+}
+
+func TestErrorMessages(t *testing.T) {
+	t.Run("should describe each kind of error", func(t *testing.T) {
+		tests := []struct {
+			err      error
+			expected string
+		}{
+			{&console.MissingArgumentError{Name: "S1"}, "console: Argument 'S1' is required"},
+			{&console.MissingArgumentError{Name: "S1", Min: 2, Values: []string{"a"}}, "console: Argument 'S1' requires at least 2 value(s)"},
+			{&console.TooManyArgumentsError{Name: "S1", Max: 1}, "console: Argument 'S1' accepts at most 1 value(s)"},
+			{&console.TooManyArgumentsError{Values: []string{"a", "b"}}, "console: Too many arguments, unexpected 'a', 'b'"},
+			{&console.MissingOptionError{Name: "--s1"}, "console: Option '--s1' is required"},
+			{&console.MissingOptionValueError{Name: "s1"}, "console: Option 's1' requires a value"},
+			{&console.UnknownOptionError{Name: "--nmae"}, "console: Unknown option '--nmae'"},
+			{&console.ConstraintError{Err: errors.New("Options '--a' and '--b' cannot be given together")}, "console: Options '--a' and '--b' cannot be given together"},
+			{&console.UnknownCommandError{Name: "lsit", Path: []string{"cluster"}}, "console: Unknown command 'cluster lsit'"},
+			{
+				&console.InvalidValueError{Parameter: "argument", Name: "S1", Value: "x", Err: errors.New("bad")},
+				"console: Invalid value 'x' for argument 'S1'. Error: bad",
+			},
+			{
+				&console.InvalidValueError{Parameter: "option", Name: "--s1", Value: "x", Source: parameters.SourceDefault, Err: errors.New("bad")},
+				"console: Invalid default value 'x' for option '--s1'. Error: bad",
+			},
+		}
+
+		for _, test := range tests {
+			assert.Equal(t, test.expected, test.err.Error())
+		}
+	})
+}
+
+func TestDefinitionSpecErrors(t *testing.T) {
+	t.Run("should panic with an inspectable syntax error for invalid specs", func(t *testing.T) {
+		defer func() {
+			err, ok := recover().(error)
+			assert.True(t, ok, "Expected to panic with an error.")
+
+			var target *specification.SyntaxError
+			assert.True(t, errors.As(err, &target), "Expected a *specification.SyntaxError.")
+			assert.Equal(t, "--s1=", target.Spec)
+		}()
+
+		var s1 string
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewStringValue(&s1),
+			Spec:  "--s1=",
+		})
+	})
+}
@@ -0,0 +1,201 @@
+package console
+
+import (
+	"encoding/json"
+
+	"github.com/eidolon/console/parameters"
+)
+
+// ExportFormatVersion is the version of the format of exported applications. It changes whenever
+// the meaning of existing fields changes, or fields are removed.
+const ExportFormatVersion = 1
+
+// ExportedApplication is a machine-readable description of an application's whole command-line
+// interface, i.e. every command, and the options and arguments each of them accepts.
+type ExportedApplication struct {
+	FormatVersion int               `json:"format_version"`
+	Name          string            `json:"name"`
+	UsageName     string            `json:"usage_name"`
+	Version       string            `json:"version"`
+	Help          string            `json:"help,omitempty"`
+	Options       []ExportedOption  `json:"options"`
+	Commands      []ExportedCommand `json:"commands"`
+}
+
+// ExportedCommand is a machine-readable description of a command, with it's resolved options and
+// arguments, and it's sub-commands.
+type ExportedCommand struct {
+	Name        string             `json:"name"`
+	Path        []string           `json:"path"`
+	Alias       string             `json:"alias,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Help        string             `json:"help,omitempty"`
+	Group       string             `json:"group,omitempty"`
+	Hidden      bool               `json:"hidden,omitempty"`
+	Deprecated  string             `json:"deprecated,omitempty"`
+	Executable  bool               `json:"executable"`
+	Arguments   []ExportedArgument `json:"arguments"`
+	Options     []ExportedOption   `json:"options"`
+	Commands    []ExportedCommand  `json:"commands"`
+}
+
+// ExportedOption is a machine-readable description of an option.
+type ExportedOption struct {
+	Names       []string `json:"names"`
+	ValueMode   string   `json:"value_mode"`
+	ValueName   string   `json:"value_name,omitempty"`
+	EnvVars     []string `json:"env_vars"`
+	Description string   `json:"description,omitempty"`
+	Required    bool     `json:"required,omitempty"`
+	Default     string   `json:"default,omitempty"`
+	Hidden      bool     `json:"hidden,omitempty"`
+	Deprecated  string   `json:"deprecated,omitempty"`
+	Inherited   bool     `json:"inherited,omitempty"`
+}
+
+// ExportedArgument is a machine-readable description of an argument.
+type ExportedArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required"`
+	Variadic    bool   `json:"variadic,omitempty"`
+	Min         int    `json:"min,omitempty"`
+	Max         int    `json:"max,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Hidden      bool   `json:"hidden,omitempty"`
+	Deprecated  string `json:"deprecated,omitempty"`
+}
+
+// exportedValueModes maps option value modes to their names in exported applications.
+var exportedValueModes = map[parameters.OptionValueMode]string{
+	parameters.OptionValueNone:     "none",
+	parameters.OptionValueOptional: "optional",
+	parameters.OptionValueRequired: "required",
+}
+
+// ExportApplication describes the whole command-line interface of the given application. Each
+// command's options and arguments are resolved by configuring a fresh definition, the same way
+// contextual help does. Hidden commands are included, and marked as hidden. Commands are sorted by
+// name, so that the result is stable.
+func ExportApplication(app *Application) ExportedApplication {
+	return ExportedApplication{
+		FormatVersion: ExportFormatVersion,
+		Name:          app.Name,
+		UsageName:     app.UsageName,
+		Version:       app.Version,
+		Help:          app.Help,
+		Options:       exportOptions(findApplicationOptions(app)),
+		Commands:      exportCommands(app, app.commands, nil),
+	}
+}
+
+// ExportJSON describes the whole command-line interface of the given application as an indented
+// JSON document. See ExportApplication.
+func ExportJSON(app *Application) ([]byte, error) {
+	data, err := json.MarshalIndent(ExportApplication(app), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+// NewExportCommand creates a hidden `export` command that prints the whole command-line interface
+// of the given application as JSON, for use by other tools. It still needs to be added to the
+// application.
+func NewExportCommand(app *Application) *Command {
+	return &Command{
+		Name:        "export",
+		Description: "Export the command-line interface as JSON.",
+		Hidden:      true,
+		Execute: func(input *Input, output *Output) error {
+			data, err := ExportJSON(app)
+			if err != nil {
+				return err
+			}
+
+			output.Print(string(data))
+
+			return nil
+		},
+	}
+}
+
+// exportCommands describes the given commands, and their sub-commands, reached by the given path.
+func exportCommands(app *Application, commands []*Command, path []string) []ExportedCommand {
+	exported := []ExportedCommand{}
+
+	for _, cmd := range sortCommands(commands) {
+		cmdPath := append(append([]string{}, path...), cmd.Name)
+		definition := buildCommandDefinition(app, cmd)
+
+		exportedCmd := ExportedCommand{
+			Name:        cmd.Name,
+			Path:        cmdPath,
+			Alias:       cmd.Alias,
+			Description: cmd.Description,
+			Help:        cmd.Help,
+			Hidden:      cmd.Hidden,
+			Deprecated:  cmd.Deprecated,
+			Executable:  cmd.executable(),
+			Arguments:   exportArguments(definition.Arguments()),
+			Options:     exportOptions(definition.Options()),
+			Commands:    exportCommands(app, cmd.commands, cmdPath),
+		}
+
+		if cmd.Group != nil {
+			exportedCmd.Group = cmd.Group.Title
+		}
+
+		exported = append(exported, exportedCmd)
+	}
+
+	return exported
+}
+
+// exportOptions describes the given options, in the order they were defined, with their names in
+// the order they were specified.
+func exportOptions(options []parameters.Option) []ExportedOption {
+	exported := []ExportedOption{}
+
+	for _, opt := range options {
+		names := append([]string{}, opt.Names...)
+		envVars := append([]string{}, opt.EnvVars...)
+
+		exported = append(exported, ExportedOption{
+			Names:       names,
+			ValueMode:   exportedValueModes[opt.ValueMode],
+			ValueName:   opt.ValueName,
+			EnvVars:     envVars,
+			Description: opt.Description,
+			Required:    opt.Required,
+			Default:     opt.Default,
+			Hidden:      opt.Hidden,
+			Deprecated:  opt.Deprecated,
+			Inherited:   opt.Inherited,
+		})
+	}
+
+	return exported
+}
+
+// exportArguments describes the given arguments, in the order they were defined.
+func exportArguments(arguments []parameters.Argument) []ExportedArgument {
+	exported := []ExportedArgument{}
+
+	for _, arg := range arguments {
+		exported = append(exported, ExportedArgument{
+			Name:        arg.Name,
+			Description: arg.Description,
+			Required:    arg.Required,
+			Variadic:    arg.Variadic,
+			Min:         arg.Min,
+			Max:         arg.Max,
+			Default:     arg.Default,
+			Hidden:      arg.Hidden,
+			Deprecated:  arg.Deprecated,
+		})
+	}
+
+	return exported
+}
@@ -0,0 +1,108 @@
+package console_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+	"github.com/seeruk/assert"
+)
+
+func TestExportApplication(t *testing.T) {
+	createApplication := func(writer *bytes.Buffer) *console.Application {
+		var value string
+
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+		application.UsageName = "app"
+		application.Writer = writer
+
+		cluster := &console.Command{
+			Name:  "cluster",
+			Alias: "c",
+			Group: &console.CommandGroup{Title: "Clusters"},
+		}
+
+		cluster.AddCommand(&console.Command{
+			Name:        "list",
+			Description: "List clusters.",
+			Configure: func(definition *console.Definition) {
+				definition.AddArgument(console.ArgumentDefinition{
+					Value: parameters.NewStringValue(&value),
+					Spec:  "NAME",
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value:  parameters.NewStringValue(&value),
+					Spec:   "-f, --format=FORMAT",
+					Desc:   "Output format.",
+					EnvVar: "APP_FORMAT",
+				})
+			},
+			Execute: func(input *console.Input, output *console.Output) error {
+				return nil
+			},
+		})
+
+		application.AddCommand(cluster)
+		application.AddCommand(console.NewExportCommand(application))
+
+		return application
+	}
+
+	t.Run("should describe every command with it's resolved options and arguments", func(t *testing.T) {
+		exported := console.ExportApplication(createApplication(&bytes.Buffer{}))
+
+		assert.Equal(t, console.ExportFormatVersion, exported.FormatVersion)
+		assert.Equal(t, "app", exported.UsageName)
+		assert.Equal(t, 2, len(exported.Commands))
+
+		cluster := exported.Commands[0]
+		assert.Equal(t, "cluster", cluster.Name)
+		assert.Equal(t, "c", cluster.Alias)
+		assert.Equal(t, "Clusters", cluster.Group)
+		assert.False(t, cluster.Executable, "Expected cluster not to be executable.")
+
+		list := cluster.Commands[0]
+		assert.Equal(t, []string{"cluster", "list"}, list.Path)
+		assert.True(t, list.Executable, "Expected list to be executable.")
+		assert.Equal(t, []console.ExportedArgument{{Name: "NAME", Required: true}}, list.Arguments)
+
+		format := list.Options[len(list.Options)-1]
+		assert.Equal(t, []string{"f", "format"}, format.Names)
+		assert.Equal(t, "required", format.ValueMode)
+		assert.Equal(t, "FORMAT", format.ValueName)
+		assert.Equal(t, []string{"APP_FORMAT"}, format.EnvVars)
+
+		export := exported.Commands[1]
+		assert.Equal(t, "export", export.Name)
+		assert.True(t, export.Hidden, "Expected hidden command to be marked as hidden.")
+	})
+
+	t.Run("should be stable", func(t *testing.T) {
+		first, err := console.ExportJSON(createApplication(&bytes.Buffer{}))
+		assert.OK(t, err)
+
+		second, err := console.ExportJSON(createApplication(&bytes.Buffer{}))
+		assert.OK(t, err)
+
+		assert.Equal(t, string(first), string(second))
+	})
+
+	t.Run("should be printed by the export command", func(t *testing.T) {
+		writer := bytes.Buffer{}
+		application := createApplication(&writer)
+
+		code := application.Run([]string{"export"}, []string{})
+		assert.Equal(t, 0, code)
+
+		var document map[string]interface{}
+
+		err := json.Unmarshal(writer.Bytes(), &document)
+		assert.OK(t, err)
+		assert.Equal(t, float64(console.ExportFormatVersion), document["format_version"])
+		assert.True(t, strings.Contains(writer.String(), `"value_mode": "required"`), "Expected value mode.")
+	})
+}
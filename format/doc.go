@@ -0,0 +1,7 @@
+// Package format contains formatters that render arbitrary values, e.g. structs, slices, and maps,
+// as aligned tables, JSON, newline-delimited JSON, YAML, or using Go templates.
+//
+// Table columns come from the exported fields of structs. The `table` struct tag sets a column's
+// header, and `table:"-"` leaves a field out. Every other format uses the value's `json` struct
+// tags, so that field names are consistent between them.
+package format
@@ -0,0 +1,60 @@
+package format
+
+import (
+	"io"
+	"reflect"
+)
+
+// DefaultName is the name of the format used when no other format has been chosen.
+const DefaultName = "table"
+
+// Formatter is a function that renders a value to a writer.
+type Formatter func(w io.Writer, value interface{}) error
+
+// Builtins gets the built-in formatters, keyed by name. A new map is returned each time, so that it
+// can safely be added to.
+func Builtins() map[string]Formatter {
+	return map[string]Formatter{
+		"json":   JSON,
+		"ndjson": NDJSON,
+		"table":  Table,
+		"yaml":   YAML,
+	}
+}
+
+// items gets the elements of the given value if it's a slice or array, and whether or not it was
+// one. Pointers are followed. Byte slices are not treated as lists of items.
+func items(value interface{}) ([]reflect.Value, bool) {
+	rv := indirect(reflect.ValueOf(value))
+	if !isList(rv) {
+		return nil, false
+	}
+
+	var result []reflect.Value
+	for i := 0; i < rv.Len(); i++ {
+		result = append(result, rv.Index(i))
+	}
+
+	return result, true
+}
+
+// indirect follows pointers and interfaces until it reaches a value that is neither, or is nil.
+func indirect(rv reflect.Value) reflect.Value {
+	for (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	return rv
+}
+
+// isList checks whether the given value is a slice or array, other than a byte slice.
+func isList(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Slice:
+		return rv.Type().Elem().Kind() != reflect.Uint8
+	case reflect.Array:
+		return true
+	}
+
+	return false
+}
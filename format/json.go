@@ -0,0 +1,46 @@
+package format
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSON renders a value as an indented JSON document.
+func JSON(w io.Writer, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// NDJSON renders a value as newline-delimited JSON, i.e. each element of a slice or array as a JSON
+// document on it's own line. Any other value is rendered on a single line. Rendering each value of a
+// stream separately produces the same result as rendering them all at once.
+func NDJSON(w io.Writer, value interface{}) error {
+	elems, ok := items(value)
+	if !ok {
+		return writeJSONLine(w, value)
+	}
+
+	for _, elem := range elems {
+		if err := writeJSONLine(w, elem.Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeJSONLine renders a value as a JSON document on a single line.
+func writeJSONLine(w io.Writer, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,51 @@
+package format_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/eidolon/console/format"
+	"github.com/seeruk/assert"
+)
+
+type node struct {
+	Name  string `json:"name"`
+	Ready bool   `json:"ready,omitempty"`
+}
+
+func TestJSON(t *testing.T) {
+	t.Run("should render an indented document using json struct tags", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		err := format.JSON(&buffer, []node{{Name: "a", Ready: true}})
+		assert.OK(t, err)
+		assert.Equal(t, "[\n  {\n    \"name\": \"a\",\n    \"ready\": true\n  }\n]\n", buffer.String())
+	})
+
+	t.Run("should return marshalling errors", func(t *testing.T) {
+		err := format.JSON(&bytes.Buffer{}, func() {})
+		assert.NotOK(t, err)
+	})
+}
+
+func TestNDJSON(t *testing.T) {
+	t.Run("should render each element on it's own line", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		err := format.NDJSON(&buffer, []node{{Name: "a"}, {Name: "b"}})
+		assert.OK(t, err)
+		assert.Equal(t, "{\"name\":\"a\"}\n{\"name\":\"b\"}\n", buffer.String())
+	})
+
+	t.Run("should render other values on a single line", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		err := format.NDJSON(&buffer, node{Name: "a"})
+		assert.OK(t, err)
+
+		err = format.NDJSON(&buffer, &node{Name: "b"})
+		assert.OK(t, err)
+
+		assert.Equal(t, "{\"name\":\"a\"}\n{\"name\":\"b\"}\n", buffer.String())
+	})
+}
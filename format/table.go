@@ -0,0 +1,240 @@
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+)
+
+// Table renders a value as a table, with columns aligned. Slices and arrays are rendered with a row
+// for each element, and anything else as a single row.
+//
+// Structs have a column for each exported field, including those of embedded structs. A column's
+// header is taken from the field's `table` struct tag, or derived from the field's name otherwise,
+// e.g. `CreatedAt` becomes `CREATED AT`. Fields tagged with `table:"-"` are left out. Maps have a
+// column for each key, in order, and any other value is rendered in a single `VALUE` column.
+func Table(w io.Writer, value interface{}) error {
+	rows, ok := items(value)
+	if !ok {
+		rows = []reflect.Value{reflect.ValueOf(value)}
+	}
+
+	columns := tableColumns(reflect.TypeOf(value), rows)
+	if len(columns) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+
+	tw := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', 0)
+
+	var headers []string
+	for _, column := range columns {
+		headers = append(headers, column.header)
+	}
+
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+
+	for _, row := range rows {
+		var cells []string
+		for _, column := range columns {
+			cells = append(cells, column.cell(indirect(row)))
+		}
+
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	// Empty cells at the end of a row are still padded, which isn't useful.
+	var table strings.Builder
+	for _, line := range strings.SplitAfter(buf.String(), "\n") {
+		if line != "" {
+			table.WriteString(strings.TrimRight(line, " \n") + "\n")
+		}
+	}
+
+	_, err := io.WriteString(w, table.String())
+	return err
+}
+
+// tableColumn is a column of a table, with a function to get it's cell in a given row.
+type tableColumn struct {
+	header string
+	cell   func(row reflect.Value) string
+}
+
+// tableColumns finds the columns of a table with the given rows, from the type of the rendered
+// value if possible, so that headers are shown even if there are no rows.
+func tableColumns(typ reflect.Type, rows []reflect.Value) []tableColumn {
+	elemType := typ
+	for elemType != nil && elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	if elemType != nil && (elemType.Kind() == reflect.Slice || elemType.Kind() == reflect.Array) {
+		elemType = elemType.Elem()
+	}
+
+	for elemType != nil && elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	if elemType != nil && elemType.Kind() == reflect.Struct {
+		return structColumns(elemType, elemType, nil)
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if first := indirect(rows[0]); first.Kind() == reflect.Struct {
+		return structColumns(first.Type(), first.Type(), nil)
+	}
+
+	if first := indirect(rows[0]); first.Kind() == reflect.Map {
+		return mapColumns(rows)
+	}
+
+	return []tableColumn{{
+		header: "VALUE",
+		cell:   formatCell,
+	}}
+}
+
+// structColumns finds the columns of a table of structs of the given row type, from the fields of
+// the given type. Fields of embedded structs are reached through the given index. Rows of any other
+// type have empty cells.
+func structColumns(rowType reflect.Type, typ reflect.Type, index []int) []tableColumn {
+	var columns []tableColumn
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fieldIndex := append(append([]int{}, index...), i)
+
+		header, tagged := field.Tag.Lookup("table")
+		if header == "-" {
+			continue
+		}
+
+		if field.Anonymous && !tagged && field.Type.Kind() == reflect.Struct {
+			columns = append(columns, structColumns(rowType, field.Type, fieldIndex)...)
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if header == "" {
+			header = headerName(field.Name)
+		}
+
+		columns = append(columns, tableColumn{
+			header: header,
+			cell: func(row reflect.Value) string {
+				if !row.IsValid() || row.Type() != rowType {
+					return ""
+				}
+
+				return formatCell(row.FieldByIndex(fieldIndex))
+			},
+		})
+	}
+
+	return columns
+}
+
+// mapColumns finds the columns of a table of maps, i.e. every key of every row, sorted.
+func mapColumns(rows []reflect.Value) []tableColumn {
+	seen := make(map[string]reflect.Value)
+
+	for _, row := range rows {
+		row = indirect(row)
+		if row.Kind() != reflect.Map {
+			continue
+		}
+
+		for _, key := range row.MapKeys() {
+			seen[fmt.Sprint(key.Interface())] = key
+		}
+	}
+
+	var names []string
+	for name := range seen {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var columns []tableColumn
+	for _, name := range names {
+		key := seen[name]
+
+		columns = append(columns, tableColumn{
+			header: strings.ToUpper(name),
+			cell: func(row reflect.Value) string {
+				if row.Kind() != reflect.Map {
+					return ""
+				}
+
+				return formatCell(row.MapIndex(key))
+			},
+		})
+	}
+
+	return columns
+}
+
+// formatCell formats a value as the content of a single cell. Nil values are empty, and lists are
+// joined by commas.
+func formatCell(rv reflect.Value) string {
+	if !rv.IsValid() {
+		return ""
+	}
+
+	if (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) && rv.IsNil() {
+		return ""
+	}
+
+	if _, ok := rv.Interface().(fmt.Stringer); !ok {
+		rv = indirect(rv)
+
+		if isList(rv) {
+			var values []string
+			for i := 0; i < rv.Len(); i++ {
+				values = append(values, formatCell(rv.Index(i)))
+			}
+
+			return strings.Join(values, ",")
+		}
+	}
+
+	cell := fmt.Sprint(rv.Interface())
+
+	// Tabs and new lines would break the alignment of the table.
+	return strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(cell)
+}
+
+// headerName derives a column header from a field name, e.g. `CreatedAt` becomes `CREATED AT`.
+func headerName(name string) string {
+	runes := []rune(name)
+
+	var header []rune
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && unicode.IsLower(runes[i-1]) {
+			header = append(header, ' ')
+		}
+
+		header = append(header, unicode.ToUpper(r))
+	}
+
+	return string(header)
+}
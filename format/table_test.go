@@ -0,0 +1,91 @@
+package format_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/eidolon/console/format"
+	"github.com/seeruk/assert"
+)
+
+type resource struct {
+	ID   int
+	Kind string `table:"TYPE"`
+}
+
+type cluster struct {
+	resource
+	Name      string
+	CreatedAt time.Duration
+	Tags      []string
+	Owner     *string
+	Secret    string `table:"-"`
+	internal  string
+}
+
+func TestTable(t *testing.T) {
+	t.Run("should render a row for each element, with headers from struct tags and names", func(t *testing.T) {
+		owner := "bob"
+		clusters := []cluster{
+			{resource{1, "k8s"}, "production", time.Minute, []string{"a", "b"}, &owner, "s", "i"},
+			{resource{22, "nomad"}, "dev", time.Second, nil, nil, "s", "i"},
+		}
+
+		buffer := bytes.Buffer{}
+
+		err := format.Table(&buffer, clusters)
+		assert.OK(t, err)
+
+		expected := "" +
+			"ID   TYPE    NAME         CREATED AT   TAGS   OWNER\n" +
+			"1    k8s     production   1m0s         a,b    bob\n" +
+			"22   nomad   dev          1s\n"
+
+		assert.Equal(t, expected, buffer.String())
+	})
+
+	t.Run("should render headers for empty slices of structs", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		err := format.Table(&buffer, []*resource{})
+		assert.OK(t, err)
+		assert.Equal(t, "ID   TYPE\n", buffer.String())
+	})
+
+	t.Run("should render a single struct as a single row", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		err := format.Table(&buffer, &resource{ID: 1, Kind: "k8s"})
+		assert.OK(t, err)
+		assert.Equal(t, "ID   TYPE\n1    k8s\n", buffer.String())
+	})
+
+	t.Run("should render empty cells for elements of another type", func(t *testing.T) {
+		type named struct {
+			Name string
+		}
+
+		buffer := bytes.Buffer{}
+
+		err := format.Table(&buffer, []interface{}{resource{1, "k8s"}, named{"a"}, &resource{2, "nomad"}, nil})
+		assert.OK(t, err)
+		assert.Equal(t, "ID   TYPE\n1    k8s\n\n2    nomad\n\n", buffer.String())
+	})
+
+	t.Run("should render a column for each key of maps", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		err := format.Table(&buffer, []map[string]string{{"name": "a"}, {"name": "b", "zone": "eu"}})
+		assert.OK(t, err)
+		assert.Equal(t, "NAME   ZONE\na\nb      eu\n", buffer.String())
+	})
+
+	t.Run("should render other values in a single column", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		err := format.Table(&buffer, []string{"a", "b\tc"})
+		assert.OK(t, err)
+		assert.Equal(t, "VALUE\na\nb c\n", buffer.String())
+	})
+}
@@ -0,0 +1,55 @@
+package format
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// templateFuncs are the functions available to templates, in addition to Go's built-ins.
+var templateFuncs = template.FuncMap{
+	"json": func(value interface{}) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// NewTemplate creates a formatter that renders values using the given Go template, e.g.
+// `{{.Name}}`. Slices and arrays are rendered by executing the template for each element, and
+// anything else by executing it once. A new line follows each execution. As well as Go's built-in
+// functions, templates may use `json`, `join`, `lower`, and `upper`.
+func NewTemplate(text string) (Formatter, error) {
+	tmpl, err := template.New("format").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w io.Writer, value interface{}) error {
+		elems, ok := items(value)
+		if !ok {
+			return executeTemplate(w, tmpl, value)
+		}
+
+		for _, elem := range elems {
+			if err := executeTemplate(w, tmpl, elem.Interface()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil
+}
+
+// executeTemplate executes a template with the given value, followed by a new line.
+func executeTemplate(w io.Writer, tmpl *template.Template, value interface{}) error {
+	if err := tmpl.Execute(w, value); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,46 @@
+package format_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/eidolon/console/format"
+	"github.com/seeruk/assert"
+)
+
+func TestNewTemplate(t *testing.T) {
+	t.Run("should execute the template for each element", func(t *testing.T) {
+		formatter, err := format.NewTemplate("{{.Name | upper}}")
+		assert.OK(t, err)
+
+		buffer := bytes.Buffer{}
+
+		err = formatter(&buffer, []node{{Name: "a"}, {Name: "b"}})
+		assert.OK(t, err)
+		assert.Equal(t, "A\nB\n", buffer.String())
+	})
+
+	t.Run("should execute the template once for other values", func(t *testing.T) {
+		formatter, err := format.NewTemplate("{{json .}}")
+		assert.OK(t, err)
+
+		buffer := bytes.Buffer{}
+
+		err = formatter(&buffer, node{Name: "a"})
+		assert.OK(t, err)
+		assert.Equal(t, "{\"name\":\"a\"}\n", buffer.String())
+	})
+
+	t.Run("should error if the template is invalid", func(t *testing.T) {
+		_, err := format.NewTemplate("{{.Name")
+		assert.NotOK(t, err)
+	})
+
+	t.Run("should return execution errors", func(t *testing.T) {
+		formatter, err := format.NewTemplate("{{.Missing}}")
+		assert.OK(t, err)
+
+		err = formatter(&bytes.Buffer{}, node{Name: "a"})
+		assert.NotOK(t, err)
+	})
+}
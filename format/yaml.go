@@ -0,0 +1,198 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// YAML renders a value as a YAML document. The value is marshalled as JSON first, so that the same
+// struct tags and marshallers apply, and the order of struct fields is kept.
+func YAML(w io.Writer, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	node, err := decodeYAMLNode(decoder)
+	if err != nil {
+		return err
+	}
+
+	var buf strings.Builder
+	writeYAMLNode(&buf, node, 0, false)
+
+	_, err = io.WriteString(w, buf.String())
+	return err
+}
+
+// yamlNode is a decoded JSON value. Exactly one of scalar, keys, and items is used, depending on
+// the kind of value.
+type yamlNode struct {
+	scalar string
+	keys   []string
+	values []*yamlNode
+	items  []*yamlNode
+	kind   json.Delim
+}
+
+// isCollection checks whether the node is a mapping or a sequence with at least one entry.
+func (n *yamlNode) isCollection() bool {
+	return len(n.keys) > 0 || len(n.items) > 0
+}
+
+// decodeYAMLNode decodes the next JSON value, keeping the order of the keys of objects.
+func decodeYAMLNode(decoder *json.Decoder) (*yamlNode, error) {
+	tok, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok := tok.(type) {
+	case json.Delim:
+		node := &yamlNode{kind: tok}
+
+		for decoder.More() {
+			if tok == '{' {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+
+				node.keys = append(node.keys, key.(string))
+			}
+
+			child, err := decodeYAMLNode(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			if tok == '{' {
+				node.values = append(node.values, child)
+			} else {
+				node.items = append(node.items, child)
+			}
+		}
+
+		// Consume the closing delimiter.
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+
+		return node, nil
+	case string:
+		return &yamlNode{scalar: quoteYAML(tok)}, nil
+	case json.Number:
+		return &yamlNode{scalar: tok.String()}, nil
+	case bool:
+		return &yamlNode{scalar: strconv.FormatBool(tok)}, nil
+	}
+
+	return &yamlNode{scalar: "null"}, nil
+}
+
+// writeYAMLNode writes a node as YAML at the given indentation. If inline is true, the first line
+// continues a line that has already been started, e.g. after the dash of a sequence item.
+func writeYAMLNode(buf *strings.Builder, node *yamlNode, indent int, inline bool) {
+	pad := strings.Repeat(" ", indent)
+
+	switch {
+	case len(node.keys) > 0:
+		for i, key := range node.keys {
+			if i > 0 || !inline {
+				buf.WriteString(pad)
+			}
+
+			buf.WriteString(quoteYAML(key) + ":")
+			writeYAMLChild(buf, node.values[i], indent)
+		}
+	case len(node.items) > 0:
+		for i, item := range node.items {
+			if i > 0 || !inline {
+				buf.WriteString(pad)
+			}
+
+			buf.WriteString("-")
+
+			if item.isCollection() {
+				buf.WriteString(" ")
+				writeYAMLNode(buf, item, indent+2, true)
+			} else {
+				buf.WriteString(" " + yamlScalar(item) + "\n")
+			}
+		}
+	default:
+		buf.WriteString(yamlScalar(node) + "\n")
+	}
+}
+
+// writeYAMLChild writes the value of a mapping key, which has already been written at the given
+// indentation.
+func writeYAMLChild(buf *strings.Builder, node *yamlNode, indent int) {
+	if !node.isCollection() {
+		buf.WriteString(" " + yamlScalar(node) + "\n")
+		return
+	}
+
+	buf.WriteString("\n")
+	writeYAMLNode(buf, node, indent+2, false)
+}
+
+// yamlScalar gets the text of a scalar node, or of an empty collection.
+func yamlScalar(node *yamlNode) string {
+	switch node.kind {
+	case '{':
+		return "{}"
+	case '[':
+		return "[]"
+	}
+
+	return node.scalar
+}
+
+// quoteYAML quotes a string if it would otherwise be read as something else, e.g. a number, a
+// boolean, or null, or wouldn't be read at all.
+func quoteYAML(s string) string {
+	if needsYAMLQuotes(s) {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
+
+// needsYAMLQuotes checks whether a string must be quoted to be read back as the same string.
+func needsYAMLQuotes(s string) bool {
+	if s == "" || strings.TrimSpace(s) != s {
+		return true
+	}
+
+	switch strings.ToLower(s) {
+	case "null", "~", "true", "false", "yes", "no", "on", "off", "y", "n":
+		return true
+	}
+
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") {
+		return true
+	}
+
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return true
+	}
+
+	for _, r := range s {
+		if r < ' ' || r == 0x7f {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package format_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/eidolon/console/format"
+	"github.com/seeruk/assert"
+)
+
+func TestYAML(t *testing.T) {
+	t.Run("should render nested values, keeping the order of struct fields", func(t *testing.T) {
+		type pool struct {
+			Zones  []string          `json:"zones"`
+			Labels map[string]string `json:"labels"`
+			Nodes  []node            `json:"nodes"`
+			Size   int               `json:"size"`
+		}
+
+		buffer := bytes.Buffer{}
+
+		err := format.YAML(&buffer, pool{
+			Zones:  []string{"eu-1", "true"},
+			Labels: map[string]string{},
+			Nodes:  []node{{Name: "a", Ready: true}, {Name: "b: c"}},
+			Size:   2,
+		})
+
+		expected := "" +
+			"zones:\n" +
+			"  - eu-1\n" +
+			"  - \"true\"\n" +
+			"labels: {}\n" +
+			"nodes:\n" +
+			"  - name: a\n" +
+			"    ready: true\n" +
+			"  - name: \"b: c\"\n" +
+			"size: 2\n"
+
+		assert.OK(t, err)
+		assert.Equal(t, expected, buffer.String())
+	})
+
+	t.Run("should render nested sequences", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		err := format.YAML(&buffer, [][]int{{1, 2}, {}})
+		assert.OK(t, err)
+		assert.Equal(t, "- - 1\n  - 2\n- []\n", buffer.String())
+	})
+
+	t.Run("should render scalars", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		err := format.YAML(&buffer, "multiple\nlines")
+		assert.OK(t, err)
+
+		err = format.YAML(&buffer, nil)
+		assert.OK(t, err)
+
+		assert.Equal(t, "\"multiple\\nlines\"\nnull\n", buffer.String())
+	})
+}
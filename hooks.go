@@ -0,0 +1,94 @@
+package console
+
+import "context"
+
+// hookLevel is a pair of hooks run around the execution of a command, and everything run inside of
+// them. Either hook may be nil.
+type hookLevel struct {
+	pre  PreRunFunc
+	post PostRunFunc
+}
+
+// runCommand executes the given command, reached by the given path, wrapped in the application's
+// middleware, and surrounded by the hooks along the command path.
+func (a *Application) runCommand(ctx context.Context, cmd *Command, path []string, input *Input, output *Output) error {
+	execute := ExecuteContextFunc(cmd.execute)
+
+	// Wrap in reverse, so that the first middleware added is the outermost.
+	for i := len(a.middleware) - 1; i >= 0; i-- {
+		execute = a.middleware[i](execute)
+	}
+
+	return executeCommand(ctx, cmd, a.findHookLevels(path), input, output, execute)
+}
+
+// ExecuteCommand executes the given command surrounded by it's own hooks, the same way an
+// Application does, with the command's timeout applied. The hooks of the command's ancestors, and
+// the application's hooks and middleware, are not run.
+func ExecuteCommand(ctx context.Context, cmd *Command, input *Input, output *Output) error {
+	levels := []hookLevel{
+		{cmd.PersistentPreRun, cmd.PersistentPostRun},
+		{cmd.PreRun, cmd.PostRun},
+	}
+
+	return executeCommand(ctx, cmd, levels, input, output, cmd.execute)
+}
+
+// executeCommand runs the execute function for the given command inside the given levels of hooks.
+// The command's timeout applies to hooks as well as execution.
+func executeCommand(ctx context.Context, cmd *Command, levels []hookLevel, input *Input, output *Output, execute ExecuteContextFunc) error {
+	if cmd.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, cmd.Timeout)
+		defer cancel()
+	}
+
+	return runHooks(ctx, levels, input, output, execute)
+}
+
+// findHookLevels finds the hooks to run for the command with the given path, in tree order, i.e.
+// the application's persistent hooks first, then those of each command along the path, ending with
+// the command's own hooks.
+func (a *Application) findHookLevels(path []string) []hookLevel {
+	levels := []hookLevel{{a.PersistentPreRun, a.PersistentPostRun}}
+
+	commands := a.findCommandsOnPath(path)
+
+	for _, cmd := range commands {
+		levels = append(levels, hookLevel{cmd.PersistentPreRun, cmd.PersistentPostRun})
+	}
+
+	if len(commands) > 0 {
+		cmd := commands[len(commands)-1]
+		levels = append(levels, hookLevel{cmd.PreRun, cmd.PostRun})
+	}
+
+	return levels
+}
+
+// runHooks runs each level of hooks around the next, and the execute function inside all of them.
+// Pre-run hooks are run in order, and post-run hooks in reverse order, so that each post-run hook
+// sees the result of everything run inside it. If a pre-run hook fails, nothing inside of it is
+// run, and neither is it's own post-run hook.
+func runHooks(ctx context.Context, levels []hookLevel, input *Input, output *Output, execute ExecuteContextFunc) error {
+	if len(levels) == 0 {
+		return execute(ctx, input, output)
+	}
+
+	level := levels[0]
+
+	if level.pre != nil {
+		if err := level.pre(ctx, input, output); err != nil {
+			return err
+		}
+	}
+
+	err := runHooks(ctx, levels[1:], input, output, execute)
+
+	if level.post != nil {
+		err = level.post(ctx, input, output, err)
+	}
+
+	return err
+}
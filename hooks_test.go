@@ -0,0 +1,172 @@
+package console_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eidolon/console"
+	"github.com/seeruk/assert"
+)
+
+func TestApplicationHooks(t *testing.T) {
+	pre := func(calls *[]string, name string, err error) console.PreRunFunc {
+		return func(ctx context.Context, input *console.Input, output *console.Output) error {
+			*calls = append(*calls, name)
+			return err
+		}
+	}
+
+	post := func(calls *[]string, name string) console.PostRunFunc {
+		return func(ctx context.Context, input *console.Input, output *console.Output, err error) error {
+			if err != nil {
+				name += " (" + err.Error() + ")"
+			}
+
+			*calls = append(*calls, name)
+			return err
+		}
+	}
+
+	createApplication := func(calls *[]string, executeErr error) *console.Application {
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+		application.Writer = &bytes.Buffer{}
+		application.PersistentPreRun = pre(calls, "app persistent pre", nil)
+		application.PersistentPostRun = post(calls, "app persistent post")
+
+		parent := &console.Command{
+			Name:              "parent",
+			PreRun:            pre(calls, "parent pre", nil),
+			PostRun:           post(calls, "parent post"),
+			PersistentPreRun:  pre(calls, "parent persistent pre", nil),
+			PersistentPostRun: post(calls, "parent persistent post"),
+		}
+
+		parent.AddCommand(&console.Command{
+			Name:              "child",
+			PreRun:            pre(calls, "child pre", nil),
+			PostRun:           post(calls, "child post"),
+			PersistentPreRun:  pre(calls, "child persistent pre", nil),
+			PersistentPostRun: post(calls, "child persistent post"),
+			Execute: func(input *console.Input, output *console.Output) error {
+				*calls = append(*calls, "execute")
+				return executeErr
+			},
+		})
+
+		application.AddCommand(parent)
+
+		return application
+	}
+
+	t.Run("should run hooks along the command path in tree order", func(t *testing.T) {
+		var calls []string
+
+		application := createApplication(&calls, nil)
+
+		code := application.Run([]string{"parent", "child"}, []string{})
+		assert.Equal(t, 0, code)
+
+		expected := []string{
+			"app persistent pre",
+			"parent persistent pre",
+			"child persistent pre",
+			"child pre",
+			"execute",
+			"child post",
+			"child persistent post",
+			"parent persistent post",
+			"app persistent post",
+		}
+
+		assert.Equal(t, expected, calls)
+	})
+
+	t.Run("should give post-run hooks the execute error", func(t *testing.T) {
+		var calls []string
+
+		application := createApplication(&calls, errors.New("failed"))
+
+		code := application.Run([]string{"parent", "child"}, []string{})
+		assert.Equal(t, 1, code)
+		assert.Equal(t, "child post (failed)", calls[5])
+		assert.Equal(t, "app persistent post (failed)", calls[8])
+	})
+
+	t.Run("should use the error returned by post-run hooks", func(t *testing.T) {
+		var calls []string
+
+		application := createApplication(&calls, errors.New("failed"))
+		application.PersistentPostRun = func(ctx context.Context, input *console.Input, output *console.Output, err error) error {
+			return nil
+		}
+
+		code := application.Run([]string{"parent", "child"}, []string{})
+		assert.Equal(t, 0, code)
+	})
+
+	t.Run("should not execute if a pre-run hook fails", func(t *testing.T) {
+		var calls []string
+
+		application := createApplication(&calls, nil)
+		application.Commands()[0].PersistentPreRun = pre(&calls, "parent persistent pre", errors.New("denied"))
+
+		code := application.Run([]string{"parent", "child"}, []string{})
+		assert.Equal(t, 1, code)
+
+		expected := []string{
+			"app persistent pre",
+			"parent persistent pre",
+			"app persistent post (denied)",
+		}
+
+		assert.Equal(t, expected, calls)
+	})
+
+	t.Run("should wrap execution in middleware, first added outermost", func(t *testing.T) {
+		var calls []string
+
+		middleware := func(name string) console.Middleware {
+			return func(next console.ExecuteContextFunc) console.ExecuteContextFunc {
+				return func(ctx context.Context, input *console.Input, output *console.Output) error {
+					calls = append(calls, name+" before")
+					err := next(ctx, input, output)
+					calls = append(calls, name+" after")
+					return err
+				}
+			}
+		}
+
+		application := createApplication(&calls, nil)
+		application.AddMiddleware(middleware("outer"))
+		application.AddMiddleware(middleware("inner"))
+
+		code := application.Run([]string{"parent", "child"}, []string{})
+		assert.Equal(t, 0, code)
+
+		expected := []string{"outer before", "inner before", "execute", "inner after", "outer after"}
+
+		assert.Equal(t, expected, calls[4:9])
+	})
+
+	t.Run("should only run the command's own hooks when executed directly", func(t *testing.T) {
+		var calls []string
+
+		application := createApplication(&calls, nil)
+		child := application.Commands()[0].Commands()[0]
+
+		err := console.ExecuteCommand(context.Background(), child, &console.Input{}, console.NewOutput(&bytes.Buffer{}))
+		assert.OK(t, err)
+
+		expected := []string{
+			"child persistent pre",
+			"child pre",
+			"execute",
+			"child post",
+			"child persistent post",
+		}
+
+		assert.Equal(t, expected, calls)
+	})
+}
@@ -6,6 +6,8 @@ package console
 type Input struct {
 	Arguments []InputArgument
 	Options   []InputOption
+	// Option values read from config files, that apply to the command being run.
+	Config []InputConfigValue
 }
 
 // InputArgument represents the raw data parsed as arguments, really this is just the value.
@@ -20,6 +22,20 @@ type InputOption struct {
 	Value string
 }
 
+// InputConfigValue represents option value(s) read from a config file, and where they were read
+// from. More than one value is read from lists.
+type InputConfigValue struct {
+	// The name of the option the value(s) are for.
+	Name string
+	// The full, dot-separated key the value(s) were read from.
+	Key string
+	// The value(s) read.
+	Values []string
+	// The file and line the value(s) were read from. The line is 0 if it's unknown.
+	File string
+	Line int
+}
+
 // GetOptionValue gets the an option with one of the given names' value./
 func (i *Input) GetOptionValue(names []string) string {
 	for _, name := range names {
@@ -33,6 +49,23 @@ func (i *Input) GetOptionValue(names []string) string {
 	return ""
 }
 
+// GetOptionValues gets the values of every occurrence of an option with one of the given names, in
+// the order they were given.
+func (i *Input) GetOptionValues(names []string) []string {
+	var values []string
+
+	for _, option := range i.Options {
+		for _, name := range names {
+			if option.Name == name {
+				values = append(values, option.Value)
+				break
+			}
+		}
+	}
+
+	return values
+}
+
 // HasOption checks to see if the given option exists by one of it's names.
 func (i *Input) HasOption(names []string) bool {
 	for _, name := range names {
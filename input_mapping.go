@@ -1,113 +1,114 @@
 package console
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/eidolon/console/parameters"
 )
 
-// MapInput maps the values of input to their corresponding reference values.
+// MapInput maps the values of input to their corresponding reference values, the same way that
+// MapInput2 does, but only returns the first problem found.
 func MapInput(definition *Definition, input *Input, env []string) error {
-	if err := mapArguments(definition.Arguments(), input); err != nil {
-		return err
+	err := MapInput2(definition, input, env)
+	if errs, ok := err.(MappingErrors); ok {
+		return errs[0]
 	}
 
-	if err := mapOptions(definition.Options(), input); err != nil {
-		return err
-	}
-
-	if err := mapEnv(definition.Options(), env); err != nil {
-		return err
-	}
-
-	return nil
+	return err
 }
 
-// mapArguments maps the values of input arguments to their corresponding references.
-func mapArguments(args []parameters.Argument, input *Input) error {
-	var unmappedArguments []parameters.Argument
-
-	for i, arg := range args {
-		if len(input.Arguments) == i {
-			unmappedArguments = append(unmappedArguments, args[i:]...)
-			break
-		}
+// valueSource records where the value of an argument or option was set from.
+type valueSource struct {
+	// The kind of source the value was set from.
+	source parameters.ValueSource
+	// Exactly where the value was set from, e.g. the name of an environment variable.
+	origin string
+}
 
-		value := input.Arguments[i].Value
+// given reports whether the value was explicitly given, rather than left at it's default.
+func (s valueSource) given() bool {
+	return s.source > parameters.SourceDefault
+}
 
-		if err := arg.Value.Set(value); err != nil {
-			return fmt.Errorf("console: Invalid value '%s' for argument '%s'. Error: %s", value, arg.Name, err)
-		}
+// setDefaultValue sets a default value on a value. Multi-values are reset either side of this, so
+// that the default is replaced by input, rather than appended to.
+func setDefaultValue(value parameters.Value, def string) error {
+	if mv, ok := value.(parameters.MultiValue); ok {
+		mv.Reset()
+		defer mv.Reset()
 	}
 
-	for _, uarg := range unmappedArguments {
-		if uarg.Required {
-			return fmt.Errorf("console: Argument '%s' is required", uarg.Name)
-		}
+	if def == "" {
+		return nil
 	}
 
-	return nil
+	return value.Set(def)
 }
 
-// mapOptions maps the values of input options to their corresponding references.
-func mapOptions(opts []parameters.Option, input *Input) error {
-	for _, opt := range opts {
-		inputOpt := findOptionInInput(opt, input)
+// parseEnv splits an array of `KEY=value` environment variables into a map.
+func parseEnv(env []string) map[string]string {
+	envMap := make(map[string]string)
 
-		if inputOpt == nil {
-			// Option not found in input
+	for _, ev := range env {
+		pair := strings.SplitN(ev, "=", 2)
+		if len(pair) != 2 {
 			continue
 		}
 
-		err := setOptionValue(opt, inputOpt.Name, inputOpt.Value)
-		if err != nil {
-			return err
-		}
+		envMap[pair[0]] = pair[1]
 	}
 
-	return nil
+	return envMap
 }
 
-// mapEnv maps the values of environment variables into their corresponding option references.
-func mapEnv(opts []parameters.Option, env []string) error {
-	envMap := make(map[string]string)
-
-	// Split array of option key and values into map.
-	for _, ev := range env {
-		pair := strings.Split(ev, "=")
-
-		envMap[pair[0]] = pair[1]
+// findConfigValue finds the last config value for one of an option's long names.
+func findConfigValue(opt parameters.Option, values []InputConfigValue) (InputConfigValue, bool) {
+	for i := len(values) - 1; i >= 0; i-- {
+		for _, name := range opt.Names {
+			if len(name) > 1 && values[i].Name == name {
+				return values[i], true
+			}
+		}
 	}
 
-	for _, opt := range opts {
-		name := ""
-		value := ""
+	return InputConfigValue{}, false
+}
 
-		for ek, ev := range envMap {
-			if ek == opt.EnvVar {
-				name = ek
-				value = ev
-			}
-		}
+// describeOptionName picks the most descriptive name of an option, for use in messages. Long names
+// are preferred over short ones.
+func describeOptionName(opt parameters.Option) string {
+	name := opt.Names[0]
 
-		if name == "" {
-			continue
+	for _, n := range opt.Names {
+		if len(n) > len(name) {
+			name = n
 		}
+	}
 
-		err := setOptionValue(opt, name, value)
-		if err != nil {
-			return err
-		}
+	return formatOptionName(name)
+}
+
+// formatOptionName adds the appropriate leading hyphens to an option name.
+func formatOptionName(name string) string {
+	if len(name) > 1 {
+		return "--" + name
 	}
 
-	return nil
+	return "-" + name
+}
+
+// markOptionSource records where an option's value was set from, by each of it's names.
+func markOptionSource(opt parameters.Option, sources map[string]valueSource, source valueSource) {
+	for _, name := range opt.Names {
+		sources[name] = source
+	}
 }
 
-// setOptionValue sets the value of an option, and handles potential error cases.
-func setOptionValue(opt parameters.Option, name string, value string) error {
+// setOptionValue sets the value of an option, and handles potential error cases. The name is the
+// name the option was given by, from the given source.
+func setOptionValue(opt parameters.Option, source parameters.ValueSource, name string, value string) error {
 	if opt.ValueMode == parameters.OptionValueRequired && value == "" {
-		return fmt.Errorf("console: Option '%s' requires a value", name)
+		return &MissingOptionValueError{Name: name, Source: source}
 	}
 
 	isEmptyOptional := opt.ValueMode == parameters.OptionValueOptional && value == ""
@@ -119,26 +120,32 @@ func setOptionValue(opt parameters.Option, name string, value string) error {
 	} else if !isEmptyOptional {
 		err := opt.Value.Set(value)
 		if err != nil {
-			return fmt.Errorf("console: Invalid value '%s' for option '%s'. Error: %s", value, name, err)
+			return &InvalidValueError{
+				Parameter: "option",
+				Name:      name,
+				Value:     value,
+				Source:    source,
+				Err:       err,
+			}
 		}
 	}
 
 	return nil
 }
 
-// findOptionInInput finds a given option in the given parsed raw input.
-func findOptionInInput(opt parameters.Option, input *Input) *InputOption {
-	inputOptions := make(map[string]InputOption)
+// findOptionsInInput finds every occurrence of a given option in the given parsed raw input, in the
+// order they were given.
+func findOptionsInInput(opt parameters.Option, input *Input) []InputOption {
+	var inputOptions []InputOption
 
 	for _, inputOption := range input.Options {
-		inputOptions[inputOption.Name] = inputOption
-	}
-
-	for _, name := range opt.Names {
-		if value, ok := inputOptions[name]; ok {
-			return &value
+		for _, name := range opt.Names {
+			if inputOption.Name == name {
+				inputOptions = append(inputOptions, inputOption)
+				break
+			}
 		}
 	}
 
-	return nil
+	return inputOptions
 }
@@ -1,6 +1,8 @@
 package console_test
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/eidolon/console"
@@ -108,6 +110,88 @@ func TestMapInput(t *testing.T) {
 		assert.Equal(t, "", s2)
 	})
 
+	t.Run("should map all remaining arguments to variadic arguments", func(t *testing.T) {
+		var s1 string
+		var ss2 []string
+
+		input := createInput([]string{"foo", "bar", "baz", "qux"})
+
+		definition := console.NewDefinition()
+		definition.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewStringValue(&s1),
+			Spec:  "S1",
+		})
+
+		definition.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewStringSliceValue(&ss2),
+			Spec:  "SS2...",
+		})
+
+		err := console.MapInput(definition, input, []string{})
+		assert.OK(t, err)
+
+		assert.Equal(t, "foo", s1)
+		assert.Equal(t, []string{"bar", "baz", "qux"}, ss2)
+	})
+
+	t.Run("should error when required variadic arguments are missing from input", func(t *testing.T) {
+		var ss1 []string
+
+		definition := console.NewDefinition()
+		definition.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewStringSliceValue(&ss1),
+			Spec:  "SS1...",
+		})
+
+		err := console.MapInput(definition, createInput([]string{}), []string{})
+		assert.NotOK(t, err)
+	})
+
+	t.Run("should not error when optional variadic arguments are missing from input", func(t *testing.T) {
+		var ss1 []string
+
+		definition := console.NewDefinition()
+		definition.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewStringSliceValue(&ss1),
+			Spec:  "[SS1...]",
+		})
+
+		err := console.MapInput(definition, createInput([]string{}), []string{})
+		assert.OK(t, err)
+		assert.Equal(t, 0, len(ss1))
+	})
+
+	t.Run("should check the number of values given to variadic arguments", func(t *testing.T) {
+		var ss1 []string
+
+		definition := console.NewDefinition()
+		definition.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewStringSliceValue(&ss1),
+			Spec:  "[SS1...]",
+			Min:   2,
+			Max:   3,
+		})
+
+		err := console.MapInput(definition, createInput([]string{"a"}), []string{})
+		assert.NotOK(t, err)
+
+		err = console.MapInput(definition, createInput([]string{"a", "b", "c", "d"}), []string{})
+		assert.NotOK(t, err)
+	})
+
+	t.Run("should error parsing variadic arguments with invalid values", func(t *testing.T) {
+		var is1 []int
+
+		definition := console.NewDefinition()
+		definition.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewIntSliceValue(&is1),
+			Spec:  "IS1...",
+		})
+
+		err := console.MapInput(definition, createInput([]string{"1", "two", "3"}), []string{})
+		assert.NotOK(t, err)
+	})
+
 	t.Run("should map short options to their reference values", func(t *testing.T) {
 		var s1 string
 		var s2 string
@@ -162,6 +246,47 @@ func TestMapInput(t *testing.T) {
 		assert.Equal(t, "qux", s2)
 	})
 
+	t.Run("should use the last occurrence of options given more than once", func(t *testing.T) {
+		var s1 string
+
+		input := createInput([]string{"--foo=bar", "-f=baz"})
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewStringValue(&s1),
+			Spec:  "-f, --foo=S1",
+		})
+
+		err := console.MapInput(definition, input, []string{})
+		assert.OK(t, err)
+
+		assert.Equal(t, "baz", s1)
+	})
+
+	t.Run("should map every occurrence of options referencing multi-values", func(t *testing.T) {
+		var ss1 []string
+		var sm1 map[string]string
+
+		input := createInput([]string{"--tag=a", "-t=b,c", "--label=env=prod", "--label=tier=web"})
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewStringSliceValue(&ss1).WithDelimiter(","),
+			Spec:  "-t, --tag=TAG",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewStringMapValue(&sm1),
+			Spec:  "--label=LABEL",
+		})
+
+		err := console.MapInput(definition, input, []string{})
+		assert.OK(t, err)
+
+		assert.Equal(t, []string{"a", "b", "c"}, ss1)
+		assert.Equal(t, map[string]string{"env": "prod", "tier": "web"}, sm1)
+	})
+
 	t.Run("should ignore options that don't exist in the definition", func(t *testing.T) {
 		var s2 string
 
@@ -274,6 +399,63 @@ func TestMapInput(t *testing.T) {
 		assert.Equal(t, "bar", s2)
 	})
 
+	t.Run("should map the first env var that is set, in order", func(t *testing.T) {
+		var s1 string
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value:   parameters.NewStringValue(&s1),
+			Spec:    "--token=TOKEN",
+			EnvVar:  "TEST_TOKEN",
+			EnvVars: []string{"TEST_LEGACY_TOKEN", "TOKEN"},
+		})
+
+		err := console.MapInput(definition, &console.Input{}, []string{
+			"TOKEN=baz",
+			"TEST_LEGACY_TOKEN=bar",
+		})
+
+		assert.OK(t, err)
+		assert.Equal(t, "bar", s1)
+
+		err = console.MapInput(definition, &console.Input{}, []string{
+			"TOKEN=baz",
+			"TEST_LEGACY_TOKEN=bar",
+			"TEST_TOKEN=foo",
+		})
+
+		assert.OK(t, err)
+		assert.Equal(t, "foo", s1)
+	})
+
+	t.Run("should split env vars for options referencing multi-values", func(t *testing.T) {
+		var ss1 []string
+		var sm1 map[string]string
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value:  parameters.NewStringSliceValue(&ss1).WithDelimiter(","),
+			Spec:   "--tag=TAG",
+			EnvVar: "TEST_TAGS",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value:  parameters.NewStringMapValue(&sm1).WithDelimiter(","),
+			Spec:   "--label=LABEL",
+			EnvVar: "TEST_LABELS",
+		})
+
+		err := console.MapInput(definition, &console.Input{}, []string{
+			"TEST_TAGS=a,b",
+			"TEST_LABELS=env=prod,tier=web",
+		})
+
+		assert.OK(t, err)
+
+		assert.Equal(t, []string{"a", "b"}, ss1)
+		assert.Equal(t, map[string]string{"env": "prod", "tier": "web"}, sm1)
+	})
+
 	t.Run("should ignore env vars that don't exist in the definition", func(t *testing.T) {
 		var s2 string
 
@@ -342,4 +524,309 @@ func TestMapInput(t *testing.T) {
 
 		assert.NotOK(t, err)
 	})
+
+	t.Run("should error when option constraints are violated", func(t *testing.T) {
+		var b1, b2 bool
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&b1),
+			Spec:  "--json",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value:  parameters.NewBoolValue(&b2),
+			Spec:   "-t, --table",
+			EnvVar: "TEST_TABLE",
+		})
+
+		definition.MutuallyExclusive("json", "table")
+
+		err := console.MapInput(definition, createInput([]string{"--json"}), []string{})
+		assert.OK(t, err)
+
+		err = console.MapInput(definition, createInput([]string{"--json", "-t"}), []string{})
+		assert.NotOK(t, err)
+
+		err = console.MapInput(definition, createInput([]string{}), []string{"TEST_TABLE=1"})
+		assert.OK(t, err)
+	})
+
+	t.Run("should let higher precedence sources override mutually exclusive options", func(t *testing.T) {
+		var b1, b2 bool
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&b1),
+			Spec:  "--json",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value:  parameters.NewBoolValue(&b2),
+			Spec:   "-t, --table",
+			EnvVar: "TEST_TABLE",
+		})
+
+		definition.MutuallyExclusive("json", "table")
+
+		input := createInput([]string{"--json"})
+		input.Config = []console.InputConfigValue{
+			{Name: "table", Key: "table", Values: []string{"true"}, File: "config.yaml", Line: 1},
+		}
+
+		err := console.MapInput(definition, input, []string{})
+		assert.OK(t, err)
+		assert.True(t, b1, "Expected json to be set from the input.")
+		assert.False(t, b2, "Expected table to be overridden.")
+		assert.False(t, definition.IsSet("table"), "Expected table not to be set.")
+
+		b1, b2 = false, false
+
+		err = console.MapInput(definition, createInput([]string{"--json"}), []string{"TEST_TABLE=1"})
+		assert.OK(t, err)
+		assert.True(t, b1, "Expected json to be set from the input.")
+		assert.False(t, b2, "Expected table to be overridden.")
+	})
+
+	t.Run("should error when mutually exclusive options are given by the same source", func(t *testing.T) {
+		var b1, b2 bool
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value:  parameters.NewBoolValue(&b1),
+			Spec:   "--json",
+			EnvVar: "TEST_JSON",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value:  parameters.NewBoolValue(&b2),
+			Spec:   "-t, --table",
+			EnvVar: "TEST_TABLE",
+		})
+
+		definition.MutuallyExclusive("json", "table")
+
+		input := createInput([]string{})
+		input.Config = []console.InputConfigValue{
+			{Name: "json", Key: "json", Values: []string{"true"}, File: "config.yaml", Line: 1},
+			{Name: "table", Key: "table", Values: []string{"true"}, File: "config.yaml", Line: 2},
+		}
+
+		err := console.MapInput(definition, input, []string{})
+		assert.NotOK(t, err)
+
+		var constraintErr *console.ConstraintError
+		assert.True(t, errors.As(err, &constraintErr), "Expected a ConstraintError.")
+		assert.Equal(t, []string{"json", "table"}, constraintErr.Names)
+
+		err = console.MapInput(definition, createInput([]string{}), []string{"TEST_JSON=1", "TEST_TABLE=1"})
+		assert.NotOK(t, err)
+	})
+
+	t.Run("should not count options that weren't given when checking constraints", func(t *testing.T) {
+		var s1, s2 string
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewStringValue(&s1),
+			Spec:  "--user=USER",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewStringValue(&s2),
+			Spec:  "--token=TOKEN",
+		})
+
+		definition.AtLeastOne("user", "token")
+
+		err := console.MapInput(definition, createInput([]string{}), []string{})
+		assert.NotOK(t, err)
+
+		err = console.MapInput(definition, createInput([]string{"--token=abc"}), []string{})
+		assert.OK(t, err)
+	})
+
+	t.Run("should error when required options are missing", func(t *testing.T) {
+		var s1 string
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value:    parameters.NewStringValue(&s1),
+			Spec:     "-c, --cluster=CLUSTER",
+			EnvVar:   "TEST_CLUSTER",
+			Required: true,
+		})
+
+		err := console.MapInput(definition, createInput([]string{}), []string{})
+		assert.NotOK(t, err)
+		assert.True(t, strings.Contains(err.Error(), "'--cluster'"), "Expected option name in error.")
+		assert.True(t, strings.Contains(err.Error(), "'TEST_CLUSTER'"), "Expected env var in error.")
+	})
+
+	t.Run("should not error when required options are given", func(t *testing.T) {
+		var s1 string
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value:    parameters.NewStringValue(&s1),
+			Spec:     "-c, --cluster=CLUSTER",
+			EnvVar:   "TEST_CLUSTER",
+			Required: true,
+		})
+
+		err := console.MapInput(definition, createInput([]string{"-c=foo"}), []string{})
+		assert.OK(t, err)
+		assert.Equal(t, "foo", s1)
+
+		err = console.MapInput(definition, createInput([]string{}), []string{"TEST_CLUSTER=bar"})
+		assert.OK(t, err)
+		assert.Equal(t, "bar", s1)
+	})
+
+	t.Run("should apply default values before mapping", func(t *testing.T) {
+		s1 := "implicit"
+		var s2 string
+		var ss1 []string
+
+		definition := console.NewDefinition()
+		definition.AddArgument(console.ArgumentDefinition{
+			Value:   parameters.NewStringValue(&s1),
+			Spec:    "[S1]",
+			Default: "World",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value:   parameters.NewStringValue(&s2),
+			Spec:    "--s2=S2",
+			Default: "foo",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value:   parameters.NewStringSliceValue(&ss1).WithDelimiter(","),
+			Spec:    "--tag=TAG",
+			Default: "a,b",
+		})
+
+		err := console.MapInput(definition, createInput([]string{}), []string{})
+		assert.OK(t, err)
+
+		assert.Equal(t, "World", s1)
+		assert.Equal(t, "foo", s2)
+		assert.Equal(t, []string{"a", "b"}, ss1)
+
+		err = console.MapInput(definition, createInput([]string{"Bob", "--s2=bar", "--tag=c"}), []string{})
+		assert.OK(t, err)
+
+		assert.Equal(t, "Bob", s1)
+		assert.Equal(t, "bar", s2)
+		assert.Equal(t, []string{"c"}, ss1)
+
+		// Mapping again restores the defaults.
+		err = console.MapInput(definition, createInput([]string{}), []string{})
+		assert.OK(t, err)
+
+		assert.Equal(t, "World", s1)
+		assert.Equal(t, "foo", s2)
+		assert.Equal(t, []string{"a", "b"}, ss1)
+	})
+
+	t.Run("should prefer input, then env vars, then config files, then defaults", func(t *testing.T) {
+		var s1, s2, s3, s4 string
+
+		definition := console.NewDefinition()
+		for _, spec := range []struct {
+			ref  *string
+			name string
+		}{{&s1, "s1"}, {&s2, "s2"}, {&s3, "s3"}, {&s4, "s4"}} {
+			definition.AddOption(console.OptionDefinition{
+				Value:   parameters.NewStringValue(spec.ref),
+				Spec:    "--" + spec.name + "=VALUE",
+				EnvVar:  "TEST_" + spec.name,
+				Default: "default",
+			})
+		}
+
+		input := createInput([]string{"--s1=input"})
+		input.Config = []console.InputConfigValue{
+			{Name: "s1", Key: "s1", Values: []string{"config"}, File: "config.yaml", Line: 1},
+			{Name: "s2", Key: "s2", Values: []string{"config"}, File: "config.yaml", Line: 2},
+			{Name: "s3", Key: "s3", Values: []string{"config"}, File: "config.yaml", Line: 3},
+		}
+
+		err := console.MapInput(definition, input, []string{"TEST_s1=env", "TEST_s2=env"})
+		assert.OK(t, err)
+
+		assert.Equal(t, "input", s1)
+		assert.Equal(t, "env", s2)
+		assert.Equal(t, "config", s3)
+		assert.Equal(t, "default", s4)
+	})
+
+	t.Run("should record where each value was set from", func(t *testing.T) {
+		var s1, s2, s3, s4, s5 string
+
+		definition := console.NewDefinition()
+		definition.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewStringValue(&s1),
+			Spec:  "S1",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewStringValue(&s2),
+			Spec:  "-a, --s2=S2",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value:  parameters.NewStringValue(&s3),
+			Spec:   "--s3=S3",
+			EnvVar: "TEST_S3",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewStringValue(&s4),
+			Spec:  "--s4=S4",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value:   parameters.NewStringValue(&s5),
+			Spec:    "--s5=S5",
+			Default: "foo",
+		})
+
+		input := createInput([]string{"bar", "-a=baz"})
+		input.Config = []console.InputConfigValue{
+			{Name: "s4", Key: "greet.s4", Values: []string{"qux"}, File: "config.yaml", Line: 3},
+		}
+
+		err := console.MapInput(definition, input, []string{"TEST_S3=quux"})
+		assert.OK(t, err)
+
+		args := definition.Arguments()
+		assert.Equal(t, parameters.SourceInput, args[0].Source)
+		assert.Equal(t, "argument 1", args[0].Origin)
+
+		opts := definition.Options()
+		assert.Equal(t, parameters.SourceInput, opts[0].Source)
+		assert.Equal(t, "-a", opts[0].Origin)
+		assert.Equal(t, parameters.SourceEnv, opts[1].Source)
+		assert.Equal(t, "TEST_S3", opts[1].Origin)
+		assert.Equal(t, parameters.SourceConfig, opts[2].Source)
+		assert.Equal(t, "config.yaml:3, key 'greet.s4'", opts[2].Origin)
+		assert.Equal(t, parameters.SourceDefault, opts[3].Source)
+		assert.Equal(t, "", opts[3].Origin)
+	})
+
+	t.Run("should error if a default value is invalid", func(t *testing.T) {
+		var i1 int
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value:   parameters.NewIntValue(&i1),
+			Spec:    "--i1=I1",
+			Default: "one",
+		})
+
+		err := console.MapInput(definition, createInput([]string{}), []string{})
+		assert.NotOK(t, err)
+	})
 }
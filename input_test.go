@@ -8,6 +8,27 @@ import (
 )
 
 func TestInput(t *testing.T) {
+	t.Run("GetOptionValues", func(t *testing.T) {
+		t.Run("should return the values of every occurrence of an option", func(t *testing.T) {
+			input := console.Input{
+				Options: []console.InputOption{
+					{Name: "t", Value: "foo"},
+					{Name: "other", Value: "bar"},
+					{Name: "tag", Value: "baz"},
+				},
+			}
+
+			values := input.GetOptionValues([]string{"t", "tag"})
+			assert.Equal(t, []string{"foo", "baz"}, values)
+		})
+
+		t.Run("should return no values if a given option doesn't exist", func(t *testing.T) {
+			input := createTestInput([]string{})
+
+			assert.Equal(t, 0, len(input.GetOptionValues([]string{"tag"})))
+		})
+	})
+
 	t.Run("HasOption", func(t *testing.T) {
 		t.Run("should return true if a given option exists", func(t *testing.T) {
 			input := createTestInput([]string{"example", "e", "foo", "bar"})
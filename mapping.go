@@ -1,22 +1,383 @@
 package console
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/eidolon/console/parameters"
 )
 
-// Mapping and parsing when combined check all input provided as application arguments and
-// environment variables against all input defined in a Definition.
+// Mapping and parsing when combined check all input provided as application arguments,
+// environment variables, and config files against all input defined in a Definition.
+
+// MappingErrors represents every problem found while mapping input, in the order they were found.
+type MappingErrors []error
+
+// Error describes each of the problems, one per line.
+func (e MappingErrors) Error() string {
+	var lines []string
+	for _, err := range e {
+		lines = append(lines, err.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap gets each of the problems, so that they may be inspected with errors.Is and errors.As.
+func (e MappingErrors) Unwrap() []error {
+	return e
+}
 
 // MapInput2 iterates over all defined possible input in the definition, and attempts to set the
-// value defined in the input, or the environment. In the process of mapping, the input will also be
-// validated (i.e. missing required params or values will be identified, and values of the wrong
-// type in the input or env will be identified).
-func MapInput2(definition *Definition, input Input, env []string) error {
+// value defined in the input, the environment, or a config file, falling back to the default. In the
+// process of mapping, the input will also be validated (i.e. missing required params or values will
+// be identified, and values of the wrong type in the input or env will be identified). Every problem
+// found is collected, and returned together as MappingErrors.
+//
+// Mutually exclusive options follow the same precedence: if one is given in the input, and another
+// in the environment or a config file, the other is ignored rather than reported, e.g. so that a
+// flag given on the command line overrides a setting in a config file. Mutually exclusive options
+// given by the same source are still reported.
+func MapInput2(definition *Definition, input *Input, env []string) error {
+	var errs MappingErrors
+
+	sources := make(map[string]valueSource)
+	definition.sources = sources
+
+	envMap := parseEnv(env)
+	floors := findSourceFloors(definition, input, envMap)
+
+	for i, arg := range definition.Arguments() {
+		errs = append(errs, mapArgument(arg, i, input, sources)...)
+	}
+
+	for _, opt := range definition.Options() {
+		errs = append(errs, mapOption(opt, input, envMap, sources, floors[opt.Names[0]])...)
+	}
+
+	for _, opt := range definition.Options() {
+		if opt.Required && !sources[opt.Names[0]].given() {
+			errs = append(errs, &MissingOptionError{Name: describeOptionName(opt), EnvVars: opt.EnvVars})
+		}
+	}
+
+	for _, constraint := range definition.Constraints() {
+		err := constraint.Check(func(name string) bool {
+			return sources[name].given()
+		})
+
+		if err != nil {
+			errs = append(errs, &ConstraintError{Kind: constraint.Kind, Names: constraint.Names, Err: err})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// CheckUnknownInput checks the input for options that aren't defined in the definition, and for
+// more arguments than the definition accepts. Each problem is collected and returned together as
+// MappingErrors. Unknown options come with suggestions of similarly named defined options, that
+// aren't hidden.
+func CheckUnknownInput(definition *Definition, input *Input) error {
+	var errs MappingErrors
+
+	var longNames []string
+	for _, opt := range visibleOptions(definition.Options()) {
+		for _, name := range opt.Names {
+			if len(name) > 1 {
+				longNames = append(longNames, formatOptionName(name))
+			}
+		}
+	}
+
+	reported := make(map[string]bool)
+
+	for _, inputOpt := range input.Options {
+		if _, ok := definition.options[inputOpt.Name]; ok || reported[inputOpt.Name] {
+			continue
+		}
+
+		reported[inputOpt.Name] = true
+
+		name := formatOptionName(inputOpt.Name)
+
+		var suggestions []string
+		if len(inputOpt.Name) > 1 {
+			suggestions = suggest(name, longNames)
+		}
+
+		errs = append(errs, &UnknownOptionError{Name: name, Suggestions: suggestions})
+	}
+
+	args := definition.Arguments()
+	isVariadic := len(args) > 0 && args[len(args)-1].Variadic
+
+	if !isVariadic && len(input.Arguments) > len(args) {
+		var values []string
+		for _, arg := range input.Arguments[len(args):] {
+			values = append(values, arg.Value)
+		}
+
+		errs = append(errs, &TooManyArgumentsError{Values: values})
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// appendMappingErrors combines the problems in two errors from mapping input into one, either of
+// which may be nil, or MappingErrors.
+func appendMappingErrors(err error, more error) error {
+	var errs MappingErrors
+
+	for _, e := range []error{err, more} {
+		if me, ok := e.(MappingErrors); ok {
+			errs = append(errs, me...)
+		} else if e != nil {
+			errs = append(errs, e)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
 	return nil
 }
 
+// mapArgument maps the value of the argument at the given position from the input, falling back to
+// it's default. A variadic argument consumes all of the remaining input arguments.
+func mapArgument(arg parameters.Argument, position int, input *Input, sources map[string]valueSource) []error {
+	if err := setDefaultValue(arg.Value, arg.Default); err != nil {
+		return []error{&InvalidValueError{
+			Parameter: "argument",
+			Name:      arg.Name,
+			Value:     arg.Default,
+			Source:    parameters.SourceDefault,
+			Err:       err,
+		}}
+	}
+
+	if arg.Default != "" {
+		sources[arg.Name] = valueSource{source: parameters.SourceDefault}
+	}
+
+	if len(input.Arguments) <= position {
+		if arg.Required {
+			return []error{&MissingArgumentError{Name: arg.Name, Min: arg.Min}}
+		}
+
+		return nil
+	}
+
+	values := input.Arguments[position : position+1]
+	if arg.Variadic {
+		values = input.Arguments[position:]
+	}
+
+	var errs []error
+	var raw []string
+
+	for _, inputArg := range values {
+		value := inputArg.Value
+		raw = append(raw, value)
+
+		if err := arg.Value.Set(value); err != nil {
+			errs = append(errs, &InvalidValueError{
+				Parameter: "argument",
+				Name:      arg.Name,
+				Value:     value,
+				Source:    parameters.SourceInput,
+				Err:       err,
+			})
+		}
+	}
+
+	if arg.Variadic && len(values) < arg.Min {
+		errs = append(errs, &MissingArgumentError{Name: arg.Name, Min: arg.Min, Values: raw})
+	}
+
+	if arg.Variadic && arg.Max > 0 && len(values) > arg.Max {
+		errs = append(errs, &TooManyArgumentsError{Name: arg.Name, Max: arg.Max, Values: raw})
+	}
+
+	sources[arg.Name] = valueSource{
+		source: parameters.SourceInput,
+		origin: fmt.Sprintf("argument %d", position+1),
+	}
+
+	return errs
+}
+
+// mapOption maps the value of an option from the source with the highest precedence that has one:
+// the input, then the environment, then config files, and then the option's default. Options that
+// reference a parameters.MultiValue are given every value from that source, other options are only
+// given the last one. Values from sources with a lower precedence than the given floor are ignored.
+func mapOption(opt parameters.Option, input *Input, env map[string]string, sources map[string]valueSource, floor parameters.ValueSource) []error {
+	if err := setDefaultValue(opt.Value, opt.Default); err != nil {
+		return []error{&InvalidValueError{
+			Parameter: "option",
+			Name:      describeOptionName(opt),
+			Value:     opt.Default,
+			Source:    parameters.SourceDefault,
+			Err:       err,
+		}}
+	}
+
+	if opt.Default != "" {
+		markOptionSource(opt, sources, valueSource{source: parameters.SourceDefault})
+	}
+
+	_, isMultiValue := opt.Value.(parameters.MultiValue)
+
+	if inputOpts := findOptionsInInput(opt, input); len(inputOpts) > 0 {
+		if !isMultiValue {
+			inputOpts = inputOpts[len(inputOpts)-1:]
+		}
+
+		var errs []error
+
+		for _, inputOpt := range inputOpts {
+			if err := setOptionValue(opt, parameters.SourceInput, inputOpt.Name, inputOpt.Value); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		markOptionSource(opt, sources, valueSource{
+			source: parameters.SourceInput,
+			origin: formatOptionName(inputOpts[len(inputOpts)-1].Name),
+		})
+
+		return errs
+	}
+
+	if floor > parameters.SourceEnv {
+		return nil
+	}
+
+	// Environment variables are checked in order, the first one that is set is used.
+	for _, envVar := range opt.EnvVars {
+		value, ok := env[envVar]
+		if !ok {
+			continue
+		}
+
+		markOptionSource(opt, sources, valueSource{source: parameters.SourceEnv, origin: envVar})
+
+		if err := setOptionValue(opt, parameters.SourceEnv, envVar, value); err != nil {
+			return []error{err}
+		}
+
+		return nil
+	}
+
+	if floor > parameters.SourceConfig {
+		return nil
+	}
+
+	// When more than one config value applies to an option, the last one is used.
+	if value, ok := findConfigValue(opt, input.Config); ok && len(value.Values) > 0 {
+		values := value.Values
+		if !isMultiValue {
+			values = values[len(values)-1:]
+		}
+
+		var errs []error
+
+		for _, v := range values {
+			if err := setOptionValue(opt, parameters.SourceConfig, value.Key, v); err != nil {
+				errs = append(errs, fmt.Errorf("%w (in %s)", err, describeConfigLocation(value)))
+			}
+		}
+
+		markOptionSource(opt, sources, valueSource{
+			source: parameters.SourceConfig,
+			origin: fmt.Sprintf("%s, key '%s'", formatConfigLocation(value), value.Key),
+		})
+
+		return errs
+	}
+
+	return nil
+}
+
+// describeConfigLocation describes where a config value was read from, for use in errors, e.g.
+// `'config.yaml' on line 3`.
+func describeConfigLocation(value InputConfigValue) string {
+	if value.Line == 0 {
+		return fmt.Sprintf("'%s'", value.File)
+	}
+
+	return fmt.Sprintf("'%s' on line %d", value.File, value.Line)
+}
+
+// formatConfigLocation formats where a config value was read from, e.g. `config.yaml:3`.
+func formatConfigLocation(value InputConfigValue) string {
+	if value.Line == 0 {
+		return value.File
+	}
+
+	return fmt.Sprintf("%s:%d", value.File, value.Line)
+}
+
+// findSourceFloors finds the lowest precedence source each option may be mapped from, by it's first
+// name. Mutually exclusive options given by a lower precedence source than another of them are
+// overridden rather than rejected, e.g. a config value for one yields to the input for another.
+func findSourceFloors(definition *Definition, input *Input, env map[string]string) map[string]parameters.ValueSource {
+	floors := make(map[string]parameters.ValueSource)
+
+	for _, constraint := range definition.Constraints() {
+		if constraint.Kind != parameters.ConstraintMutuallyExclusive {
+			continue
+		}
+
+		highest := parameters.SourceNone
+
+		for _, name := range constraint.Names {
+			if source := findOptionSource(definition.options[name], input, env); source > highest {
+				highest = source
+			}
+		}
+
+		for _, name := range constraint.Names {
+			key := definition.options[name].Names[0]
+
+			if highest > floors[key] {
+				floors[key] = highest
+			}
+		}
+	}
+
+	return floors
+}
+
+// findOptionSource finds the source with the highest precedence that has a value for the given
+// option, ignoring it's default.
+func findOptionSource(opt parameters.Option, input *Input, env map[string]string) parameters.ValueSource {
+	if len(findOptionsInInput(opt, input)) > 0 {
+		return parameters.SourceInput
+	}
+
+	for _, envVar := range opt.EnvVars {
+		if _, ok := env[envVar]; ok {
+			return parameters.SourceEnv
+		}
+	}
+
+	if value, ok := findConfigValue(opt, input.Config); ok && len(value.Values) > 0 {
+		return parameters.SourceConfig
+	}
+
+	return parameters.SourceNone
+}
+
 // ParseInput2 takes the raw input, and regardless of what is actually defined in the definition,
 // categorising the input as either arguments or options. In other words, the raw input is iterated
 // over, not the definition's parameters. The definition is used so that we can identify options
@@ -63,14 +424,11 @@ func ParseInput2(definition *Definition, args []string) *Input {
 			if mappedOptionsLen > 0 {
 				lastOption := mappedOptions[mappedOptionsLen-1]
 
+				// Options that don't exist in the definition are still parsed, but we shouldn't be
+				// consuming arguments for them, because they won't require a value.
 				defOpt, exists := definition.options[lastOption.Name]
-				if !exists {
-					// We don't care about options that don't exist in the definition. We shouldn't
-					// be consuming arguments for them, because they won't require a value.
-					break
-				}
 
-				isRequired := defOpt.ValueMode == parameters.OptionValueRequired
+				isRequired := exists && defOpt.ValueMode == parameters.OptionValueRequired
 				hasArgsLeft := len(args) > (i + 1) // Length required for next is +2, not +1.
 				hasNoValYet := lastOption.Value == ""
 
@@ -0,0 +1,246 @@
+package console_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+	"github.com/seeruk/assert"
+)
+
+func TestMapInput2(t *testing.T) {
+	createDefinition := func() *console.Definition {
+		var s1, s2 string
+		var i1, i2 int
+
+		definition := console.NewDefinition()
+		definition.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewIntValue(&i1),
+			Spec:  "I1",
+		})
+
+		definition.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewStringValue(&s1),
+			Spec:  "S1",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewIntValue(&i2),
+			Spec:  "--i2=I2",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewStringValue(&s2),
+			Spec:  "--s2=S2",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value:    parameters.NewStringValue(&s2),
+			Spec:     "--s3=S3",
+			Required: true,
+		})
+
+		return definition
+	}
+
+	t.Run("should map input to it's reference values", func(t *testing.T) {
+		var s1 string
+		var i1 int
+
+		definition := console.NewDefinition()
+		definition.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewStringValue(&s1),
+			Spec:  "S1",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value:  parameters.NewIntValue(&i1),
+			Spec:   "--i1=I1",
+			EnvVar: "TEST_I1",
+		})
+
+		err := console.MapInput2(definition, console.ParseInput([]string{"foo"}), []string{"TEST_I1=42"})
+		assert.OK(t, err)
+
+		assert.Equal(t, "foo", s1)
+		assert.Equal(t, 42, i1)
+	})
+
+	t.Run("should report every problem at once", func(t *testing.T) {
+		input := console.ParseInput([]string{"one", "--i2=two", "--s2"})
+
+		err := console.MapInput2(createDefinition(), input, []string{})
+		assert.NotOK(t, err)
+
+		errs, ok := err.(console.MappingErrors)
+		assert.True(t, ok, "Expected console.MappingErrors.")
+		assert.Equal(t, 5, len(errs))
+
+		message := err.Error()
+		assert.True(t, strings.Contains(message, "Invalid value 'one' for argument 'I1'"), "Expected bad argument.")
+		assert.True(t, strings.Contains(message, "Argument 'S1' is required"), "Expected missing argument.")
+		assert.True(t, strings.Contains(message, "Invalid value 'two' for option 'i2'"), "Expected bad option.")
+		assert.True(t, strings.Contains(message, "Option 's2' requires a value"), "Expected missing value.")
+		assert.True(t, strings.Contains(message, "Option '--s3' is required"), "Expected missing option.")
+	})
+
+	t.Run("should report every violated option constraint", func(t *testing.T) {
+		var a, b, c bool
+
+		definition := console.NewDefinition()
+		for _, spec := range []struct {
+			ref  *bool
+			name string
+		}{{&a, "a"}, {&b, "b"}, {&c, "c"}} {
+			definition.AddOption(console.OptionDefinition{
+				Value: parameters.NewBoolValue(spec.ref),
+				Spec:  "-" + spec.name,
+			})
+		}
+
+		definition.MutuallyExclusive("a", "b")
+		definition.DependsOn("a", "c")
+
+		err := console.MapInput2(definition, console.ParseInput([]string{"-a", "-b"}), []string{})
+		assert.NotOK(t, err)
+		assert.Equal(t, 2, len(err.(console.MappingErrors)))
+	})
+
+	t.Run("should return only the first problem from MapInput", func(t *testing.T) {
+		input := console.ParseInput([]string{"one", "--i2=two"})
+
+		err := console.MapInput(createDefinition(), input, []string{})
+		assert.NotOK(t, err)
+
+		_, ok := err.(console.MappingErrors)
+		assert.False(t, ok, "Expected a single error.")
+		assert.True(t, strings.Contains(err.Error(), "Invalid value 'one'"), "Expected first problem.")
+	})
+}
+
+func TestMappingErrors(t *testing.T) {
+	t.Run("Error()", func(t *testing.T) {
+		t.Run("should describe each problem on it's own line", func(t *testing.T) {
+			errs := console.MappingErrors{errors.New("foo"), errors.New("bar")}
+
+			assert.Equal(t, "foo\nbar", errs.Error())
+		})
+	})
+
+	t.Run("should allow inspecting each problem", func(t *testing.T) {
+		target := errors.New("bar")
+		errs := console.MappingErrors{errors.New("foo"), target}
+
+		assert.True(t, errors.Is(errs, target), "Expected to find wrapped error.")
+	})
+}
+
+func TestParseInput2(t *testing.T) {
+	t.Run("should consume the next argument as the value of options that require one", func(t *testing.T) {
+		var s1 string
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewStringValue(&s1),
+			Spec:  "--s1=S1",
+		})
+
+		input := console.ParseInput2(definition, []string{"--s1", "foo", "bar"})
+
+		assert.Equal(t, []console.InputOption{{Name: "s1", Value: "foo"}}, input.Options)
+		assert.Equal(t, []console.InputArgument{{Value: "bar"}}, input.Arguments)
+	})
+
+	t.Run("should keep parsing after options that aren't defined", func(t *testing.T) {
+		input := console.ParseInput2(console.NewDefinition(), []string{"--unknown", "foo", "-x", "bar"})
+
+		assert.Equal(t, []console.InputOption{{Name: "unknown"}, {Name: "x"}}, input.Options)
+		assert.Equal(t, []console.InputArgument{{Value: "foo"}, {Value: "bar"}}, input.Arguments)
+	})
+}
+
+func TestCheckUnknownInput(t *testing.T) {
+	createDefinition := func(variadic bool) *console.Definition {
+		var s1 string
+		var ss1 []string
+		var verbose bool
+
+		definition := console.NewDefinition()
+		if variadic {
+			definition.AddArgument(console.ArgumentDefinition{
+				Value: parameters.NewStringSliceValue(&ss1),
+				Spec:  "S1...",
+			})
+		} else {
+			definition.AddArgument(console.ArgumentDefinition{
+				Value: parameters.NewStringValue(&s1),
+				Spec:  "S1",
+			})
+		}
+
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&verbose),
+			Spec:  "-v, --verbose",
+		})
+
+		return definition
+	}
+
+	t.Run("should not error if all input is defined", func(t *testing.T) {
+		definition := createDefinition(false)
+
+		err := console.CheckUnknownInput(definition, console.ParseInput2(definition, []string{"foo", "-v"}))
+		assert.OK(t, err)
+	})
+
+	t.Run("should error for unknown options, with suggestions", func(t *testing.T) {
+		definition := createDefinition(false)
+
+		input := console.ParseInput2(definition, []string{"foo", "--verbsoe", "--verbsoe", "-x"})
+
+		err := console.CheckUnknownInput(definition, input)
+		assert.NotOK(t, err)
+
+		errs := err.(console.MappingErrors)
+		assert.Equal(t, 2, len(errs))
+
+		var target *console.UnknownOptionError
+		assert.True(t, errors.As(errs[0], &target), "Expected a *console.UnknownOptionError.")
+		assert.Equal(t, "--verbsoe", target.Name)
+		assert.Equal(t, []string{"--verbose"}, target.Suggestions)
+		assert.Equal(t, "console: Unknown option '--verbsoe', did you mean '--verbose'?", target.Error())
+
+		assert.True(t, errors.As(errs[1], &target), "Expected a *console.UnknownOptionError.")
+		assert.Equal(t, "-x", target.Name)
+		assert.Equal(t, 0, len(target.Suggestions))
+	})
+
+	t.Run("should not suggest options with very different names", func(t *testing.T) {
+		definition := createDefinition(false)
+
+		err := console.CheckUnknownInput(definition, console.ParseInput2(definition, []string{"foo", "--quiet"}))
+
+		var target *console.UnknownOptionError
+		assert.True(t, errors.As(err, &target), "Expected a *console.UnknownOptionError.")
+		assert.Equal(t, 0, len(target.Suggestions))
+	})
+
+	t.Run("should error for surplus arguments", func(t *testing.T) {
+		definition := createDefinition(false)
+
+		err := console.CheckUnknownInput(definition, console.ParseInput2(definition, []string{"foo", "bar", "baz"}))
+
+		var target *console.TooManyArgumentsError
+		assert.True(t, errors.As(err, &target), "Expected a *console.TooManyArgumentsError.")
+		assert.Equal(t, []string{"bar", "baz"}, target.Values)
+	})
+
+	t.Run("should not error for many arguments given to variadic arguments", func(t *testing.T) {
+		definition := createDefinition(true)
+
+		err := console.CheckUnknownInput(definition, console.ParseInput2(definition, []string{"foo", "bar", "baz"}))
+		assert.OK(t, err)
+	})
+}
@@ -3,40 +3,173 @@ package console
 import (
 	"fmt"
 	"io"
+	"os"
+
+	"github.com/eidolon/console/format"
+	"github.com/eidolon/console/style"
 )
 
 // Output abstracts application output. This is mainly useful for testing, as a different writer can
 // be passed to capture output in an easy to test manner.
 type Output struct {
-	Writer   io.Writer
+	Writer io.Writer
+	// Writer to write errors, warnings, and other diagnostics to. Defaults to os.Stderr if nil.
+	ErrorWriter io.Writer
+	// Name of the format that Render uses, e.g. "json". Defaults to "table" if empty.
+	Format string
+	// Go template that Render uses, e.g. "{{.Name}}". Takes precedence over Format if set.
+	Template string
+	// Whether or not markup in text written by Print, and it's variants, is rendered as colors and
+	// styles, or stripped. If false, text is written as it is.
+	Markup bool
+	// Whether or not markup is rendered as colors and styles. Defaults to ColorAuto if empty.
+	Color ColorMode
+	// Formatters available to Render, keyed by name.
+	formatters map[string]format.Formatter
+	// Renderer for markup, knowing about the available styles.
+	styles *style.Renderer
+	// Environment used to decide whether or not to use colors, os.Getenv is used if nil.
+	env      map[string]string
 	exitCode int
 }
 
-// NewOutput creates a new Output.
+// NewOutput creates a new Output. Diagnostics are written to os.Stderr.
 func NewOutput(writer io.Writer) *Output {
 	return &Output{
-		Writer:   writer,
-		exitCode: 0,
+		Writer:      writer,
+		ErrorWriter: os.Stderr,
+		Color:       ColorAuto,
+		formatters:  format.Builtins(),
+		styles:      style.NewRenderer(),
+		exitCode:    0,
 	}
 }
 
 // Print uses the fmt package's Print with a pre-set writer. Spaces are always added between
-// operands. It returns the number of bytes written and any write error encountered.
+// operands. If enabled, markup is rendered as styles, or stripped, see the style package. It returns
+// the number of bytes written and any write error encountered.
 func (o *Output) Print(a ...interface{}) (int, error) {
-	return fmt.Fprint(o.Writer, a...)
+	return o.write(o.Writer, fmt.Sprint(a...))
 }
 
-// Printf uses the fmt package's Printf with a pre-set writer. It returns the number of bytes
-// written and any write error encountered.
+// Printf uses the fmt package's Printf with a pre-set writer. Markup is rendered the same way as in
+// Print. It returns the number of bytes written and any write error encountered.
 func (o *Output) Printf(format string, a ...interface{}) (int, error) {
-	return fmt.Fprintf(o.Writer, format, a...)
+	return o.write(o.Writer, fmt.Sprintf(format, a...))
 }
 
 // Println uses the fmt package's Println with a pre-set writer. Spaces are always added between
-// operands and a newline is appended. It returns the number of bytes written and any write error
-// encountered.
+// operands and a newline is appended. Markup is rendered the same way as in Print. It returns the
+// number of bytes written and any write error encountered.
 func (o *Output) Println(a ...interface{}) (int, error) {
-	return fmt.Fprintln(o.Writer, a...)
+	return o.write(o.Writer, fmt.Sprintln(a...))
+}
+
+// Eprint is like Print, but writes to the error writer. It should be used for errors, warnings, and
+// other diagnostics, so that they aren't mixed in with output that may be piped elsewhere.
+func (o *Output) Eprint(a ...interface{}) (int, error) {
+	return o.write(o.errorWriter(), fmt.Sprint(a...))
+}
+
+// Eprintf is like Printf, but writes to the error writer.
+func (o *Output) Eprintf(format string, a ...interface{}) (int, error) {
+	return o.write(o.errorWriter(), fmt.Sprintf(format, a...))
+}
+
+// Eprintln is like Println, but writes to the error writer.
+func (o *Output) Eprintln(a ...interface{}) (int, error) {
+	return o.write(o.errorWriter(), fmt.Sprintln(a...))
+}
+
+// AddStyle makes a style available to markup, by the given name. Built-in styles may be replaced.
+func (o *Output) AddStyle(name string, s style.Style) {
+	o.renderer().AddStyle(name, s)
+}
+
+// write renders the markup in the given text for the given writer, if markup is enabled, and writes
+// it.
+func (o *Output) write(w io.Writer, text string) (int, error) {
+	if !o.Markup {
+		return io.WriteString(w, text)
+	}
+
+	return io.WriteString(w, o.renderer().Render(text, o.isColored(w)))
+}
+
+// escape escapes the given text, if markup is enabled, so that it's written as it is.
+func (o *Output) escape(text string) string {
+	if !o.Markup {
+		return text
+	}
+
+	return style.Escape(text)
+}
+
+// renderer gets the renderer for markup.
+func (o *Output) renderer() *style.Renderer {
+	if o.styles == nil {
+		o.styles = style.NewRenderer()
+	}
+
+	return o.styles
+}
+
+// errorWriter gets the writer to write diagnostics to.
+func (o *Output) errorWriter() io.Writer {
+	if o.ErrorWriter == nil {
+		return os.Stderr
+	}
+
+	return o.ErrorWriter
+}
+
+// Render writes a value, e.g. a struct, slice, or map, to the writer using the chosen format, or
+// template. See the format package for how each of the built-in formats renders values.
+func (o *Output) Render(value interface{}) error {
+	formatter, err := o.formatter()
+	if err != nil {
+		return err
+	}
+
+	return formatter(o.Writer, value)
+}
+
+// AddFormatter makes a formatter available to Render, by the given name. Built-in formatters may be
+// replaced.
+func (o *Output) AddFormatter(name string, formatter format.Formatter) {
+	if o.formatters == nil {
+		o.formatters = format.Builtins()
+	}
+
+	o.formatters[name] = formatter
+}
+
+// formatter gets the formatter that Render uses, based on the chosen format, or template.
+func (o *Output) formatter() (format.Formatter, error) {
+	if o.Template != "" {
+		formatter, err := format.NewTemplate(o.Template)
+		if err != nil {
+			return nil, fmt.Errorf("console: Invalid output template: %v", err)
+		}
+
+		return formatter, nil
+	}
+
+	name := o.Format
+	if name == "" {
+		name = format.DefaultName
+	}
+
+	if o.formatters == nil {
+		o.formatters = format.Builtins()
+	}
+
+	formatter, ok := o.formatters[name]
+	if !ok {
+		return nil, fmt.Errorf("console: Unknown output format '%s'", name)
+	}
+
+	return formatter, nil
 }
 
 // SetExitCode sets the exit code to a specific int. By default, the exit code is set to 0.
@@ -0,0 +1,85 @@
+package console
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/eidolon/console/format"
+	"github.com/eidolon/console/parameters"
+)
+
+// outputOptionName is the name of the global option used to choose an output format.
+const outputOptionName = "output"
+
+// templateOptionName is the name of the global option used to give a Go template to format output.
+const templateOptionName = "format"
+
+// AddOutputFormat makes a formatter available to Output.Render, and the `--output` option, by the
+// given name. Built-in formats may be replaced.
+func (a *Application) AddOutputFormat(name string, formatter format.Formatter) {
+	if a.outputFormatters == nil {
+		a.outputFormatters = make(map[string]format.Formatter)
+	}
+
+	a.outputFormatters[name] = formatter
+}
+
+// configureOutputFormat adds the global options used to choose an output format, or template, if
+// a default output format is set.
+func (a *Application) configureOutputFormat(definition *Definition) {
+	if a.OutputFormat == "" {
+		return
+	}
+
+	var outputFormat string
+	var outputTemplate string
+
+	names := a.outputFormatNames()
+
+	definition.AddOption(OptionDefinition{
+		Value:   parameters.NewEnumValue(&outputFormat, names...),
+		Spec:    "-o, --" + outputOptionName + "=FORMAT",
+		Desc:    fmt.Sprintf("Output format, one of '%s'.", strings.Join(names, "', '")),
+		Default: a.OutputFormat,
+	})
+
+	definition.AddOption(OptionDefinition{
+		Value: parameters.NewStringValue(&outputTemplate),
+		Spec:  "--" + templateOptionName + "=TEMPLATE",
+		Desc:  "Go template to format output with, e.g. '{{.Name}}'. Overrides --output.",
+	})
+}
+
+// applyOutputFormat passes the output format, or template, chosen with the global options, in the
+// input, the environment, or a config file, on to the output, checking that a template is valid
+// before any command is run.
+func (a *Application) applyOutputFormat() error {
+	if a.OutputFormat == "" {
+		return nil
+	}
+
+	a.output.Format = a.definition.optionValue(outputOptionName)
+	a.output.Template = a.definition.optionValue(templateOptionName)
+
+	_, err := a.output.formatter()
+	return err
+}
+
+// outputFormatNames gets the names of the built-in output formats, and those that have been added,
+// sorted.
+func (a *Application) outputFormatNames() []string {
+	formatters := format.Builtins()
+	for name, formatter := range a.outputFormatters {
+		formatters[name] = formatter
+	}
+
+	var names []string
+	for name := range formatters {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
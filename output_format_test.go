@@ -0,0 +1,105 @@
+package console_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/eidolon/console"
+	"github.com/seeruk/assert"
+)
+
+func TestApplicationOutputFormat(t *testing.T) {
+	type cluster struct {
+		Name string `json:"name"`
+		Size int    `json:"size"`
+	}
+
+	createApplication := func(writer *bytes.Buffer, errWriter *bytes.Buffer) *console.Application {
+		application := console.NewApplication("eidolon/console", "1.2.3+testing")
+		application.Writer = writer
+		application.ErrorWriter = errWriter
+		application.OutputFormat = "table"
+		application.AddCommand(&console.Command{
+			Name: "list",
+			Execute: func(input *console.Input, output *console.Output) error {
+				return output.Render([]cluster{{Name: "a", Size: 1}})
+			},
+		})
+
+		return application
+	}
+
+	t.Run("should render using the default format", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		code := createApplication(&writer, &bytes.Buffer{}).Run([]string{"list"}, []string{})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "NAME   SIZE\na      1\n", writer.String())
+	})
+
+	t.Run("should render using the format chosen with --output", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		code := createApplication(&writer, &bytes.Buffer{}).Run([]string{"list", "-o", "yaml"}, []string{})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "- name: a\n  size: 1\n", writer.String())
+	})
+
+	t.Run("should render using the template given with --format", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		code := createApplication(&writer, &bytes.Buffer{}).Run([]string{"list", "--format", "{{.Name}}"}, []string{})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "a\n", writer.String())
+	})
+
+	t.Run("should render using added formats", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		application := createApplication(&writer, &bytes.Buffer{})
+		application.AddOutputFormat("count", func(w io.Writer, value interface{}) error {
+			_, err := fmt.Fprintln(w, len(value.([]cluster)))
+			return err
+		})
+
+		code := application.Run([]string{"list", "--output=count"}, []string{})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "1\n", writer.String())
+	})
+
+	t.Run("should exit with code 101 if the format is unknown", func(t *testing.T) {
+		errWriter := bytes.Buffer{}
+
+		code := createApplication(&bytes.Buffer{}, &errWriter).Run([]string{"list", "-o", "xml"}, []string{})
+
+		assert.Equal(t, 101, code)
+		assert.True(t, strings.Contains(errWriter.String(), "'json', 'ndjson', 'table', 'yaml'"), "Expected choices.")
+	})
+
+	t.Run("should exit with code 101 if the template is invalid", func(t *testing.T) {
+		errWriter := bytes.Buffer{}
+
+		code := createApplication(&bytes.Buffer{}, &errWriter).Run([]string{"list", "--format", "{{.Name"}, []string{})
+
+		assert.Equal(t, 101, code)
+		assert.True(t, strings.Contains(errWriter.String(), "Invalid output template"), "Expected error.")
+	})
+
+	t.Run("should not add the output options unless a default format is set", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		application := createApplication(&writer, &bytes.Buffer{})
+		application.OutputFormat = ""
+		application.Run([]string{"list", "--help"}, []string{})
+
+		assert.False(t, strings.Contains(writer.String(), "--output"), "Expected no output option.")
+		assert.False(t, strings.Contains(writer.String(), "--format"), "Expected no format option.")
+	})
+}
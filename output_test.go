@@ -2,9 +2,12 @@ package console_test
 
 import (
 	"bytes"
+	"fmt"
+	"io"
 	"testing"
 
 	"github.com/eidolon/console"
+	"github.com/eidolon/console/style"
 	"github.com/seeruk/assert"
 )
 
@@ -111,4 +114,174 @@ func TestOutput(t *testing.T) {
 			assert.Equal(t, expected, buffer.String())
 		})
 	})
+
+	t.Run("Eprint()", func(t *testing.T) {
+		t.Run("should print to the error writer", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			errBuffer := bytes.Buffer{}
+
+			output := console.NewOutput(&buffer)
+			output.ErrorWriter = &errBuffer
+
+			nbytes, err := output.Eprint("Hello, World!")
+
+			assert.OK(t, err)
+			assert.Equal(t, 13, nbytes)
+			assert.Equal(t, "Hello, World!", errBuffer.String())
+			assert.Equal(t, "", buffer.String())
+		})
+	})
+
+	t.Run("Eprintf()", func(t *testing.T) {
+		t.Run("should print to the error writer", func(t *testing.T) {
+			errBuffer := bytes.Buffer{}
+
+			output := console.NewOutput(&bytes.Buffer{})
+			output.ErrorWriter = &errBuffer
+
+			nbytes, err := output.Eprintf("Hello, %s!", "World")
+
+			assert.OK(t, err)
+			assert.Equal(t, 13, nbytes)
+			assert.Equal(t, "Hello, World!", errBuffer.String())
+		})
+	})
+
+	t.Run("Eprintln()", func(t *testing.T) {
+		t.Run("should print to the error writer", func(t *testing.T) {
+			errBuffer := bytes.Buffer{}
+
+			output := console.NewOutput(&bytes.Buffer{})
+			output.ErrorWriter = &errBuffer
+
+			nbytes, err := output.Eprintln("Hello, World!")
+
+			assert.OK(t, err)
+			assert.Equal(t, 14, nbytes)
+			assert.Equal(t, "Hello, World!\n", errBuffer.String())
+		})
+	})
+
+	t.Run("Render()", func(t *testing.T) {
+		type cluster struct {
+			Name string `json:"name"`
+			Size int    `json:"size"`
+		}
+
+		clusters := []cluster{{Name: "a", Size: 1}, {Name: "b", Size: 3}}
+
+		t.Run("should render a table by default", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			output := console.NewOutput(&buffer)
+
+			err := output.Render(clusters)
+
+			assert.OK(t, err)
+			assert.Equal(t, "NAME   SIZE\na      1\nb      3\n", buffer.String())
+		})
+
+		t.Run("should render using the chosen format", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			output := console.NewOutput(&buffer)
+			output.Format = "ndjson"
+
+			err := output.Render(clusters)
+
+			assert.OK(t, err)
+			assert.Equal(t, "{\"name\":\"a\",\"size\":1}\n{\"name\":\"b\",\"size\":3}\n", buffer.String())
+		})
+
+		t.Run("should render using the template, if one is given", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			output := console.NewOutput(&buffer)
+			output.Format = "json"
+			output.Template = "{{.Name}}={{.Size}}"
+
+			err := output.Render(clusters)
+
+			assert.OK(t, err)
+			assert.Equal(t, "a=1\nb=3\n", buffer.String())
+		})
+
+		t.Run("should render using added formatters", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			output := console.NewOutput(&buffer)
+			output.Format = "count"
+			output.AddFormatter("count", func(w io.Writer, value interface{}) error {
+				_, err := fmt.Fprintln(w, len(value.([]cluster)))
+				return err
+			})
+
+			err := output.Render(clusters)
+
+			assert.OK(t, err)
+			assert.Equal(t, "2\n", buffer.String())
+		})
+
+		t.Run("should error if the format is unknown", func(t *testing.T) {
+			output := console.NewOutput(&bytes.Buffer{})
+			output.Format = "xml"
+
+			err := output.Render(clusters)
+			assert.NotOK(t, err)
+		})
+	})
+
+	t.Run("markup", func(t *testing.T) {
+		t.Run("should be written as it is unless enabled", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			output := console.NewOutput(&buffer)
+			output.Color = console.ColorAlways
+
+			output.Print("<b>bold</b> \\<u>")
+
+			assert.Equal(t, "<b>bold</b> \\<u>", buffer.String())
+		})
+
+		t.Run("should be rendered as styles if colors are always used", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			output := console.NewOutput(&buffer)
+			output.Markup = true
+			output.Color = console.ColorAlways
+
+			output.Printf("<info>%s</info>", "done")
+
+			assert.Equal(t, "\x1b[32mdone\x1b[0m", buffer.String())
+		})
+
+		t.Run("should be stripped if colors are never used", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			output := console.NewOutput(&buffer)
+			output.Markup = true
+			output.Color = console.ColorNever
+
+			output.Println("<error>failed</error>")
+
+			assert.Equal(t, "failed\n", buffer.String())
+		})
+
+		t.Run("should be rendered with added styles", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			output := console.NewOutput(&buffer)
+			output.Markup = true
+			output.Color = console.ColorAlways
+			output.AddStyle("warning", style.Style{Foreground: "yellow", Bold: true})
+
+			output.Print("<warning>careful</>")
+
+			assert.Equal(t, "\x1b[33;1mcareful\x1b[0m", buffer.String())
+		})
+
+		t.Run("should be rendered in diagnostics", func(t *testing.T) {
+			errBuffer := bytes.Buffer{}
+			output := console.NewOutput(&bytes.Buffer{})
+			output.ErrorWriter = &errBuffer
+			output.Markup = true
+			output.Color = console.ColorNever
+
+			output.Eprint("<comment>Warning:</comment> careful")
+
+			assert.Equal(t, "Warning: careful", errBuffer.String())
+		})
+	})
 }
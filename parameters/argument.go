@@ -10,4 +10,20 @@ type Argument struct {
 	Value Value
 	// Is this argument required?
 	Required bool
+	// The default value of this argument, applied before mapping input.
+	Default string
+	// Does this argument collect all remaining input arguments?
+	Variadic bool
+	// The minimum number of values a variadic argument accepts, if any are given.
+	Min int
+	// The maximum number of values a variadic argument accepts, 0 meaning no limit.
+	Max int
+	// Is this argument left out of contextual help and completion?
+	Hidden bool
+	// If set, the argument is deprecated, and this describes what to use instead.
+	Deprecated string
+	// Where this argument's value was set from when input was last mapped.
+	Source ValueSource
+	// Exactly where this argument's value was set from, e.g. it's position in the input.
+	Origin string
 }
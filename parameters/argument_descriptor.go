@@ -8,7 +8,8 @@ import (
 	"github.com/eidolon/wordwrap"
 )
 
-// DescribeArguments describes an array of Arguments, formatting them in a helpful way.
+// DescribeArguments describes an array of Arguments, formatting them in a helpful way. Hidden
+// arguments are not described.
 func DescribeArguments(arguments []Argument) string {
 	desc := "ARGUMENTS:\n"
 
@@ -18,8 +19,14 @@ func DescribeArguments(arguments []Argument) string {
 
 	// Generate the list of names and description to allow specific output ordering.
 	for _, arg := range arguments {
-		argDescKeys = append(argDescKeys, arg.Name)
-		argDescMap[arg.Name] = arg.Description
+		if arg.Hidden {
+			continue
+		}
+
+		key, description := DescribeArgument(arg)
+
+		argDescKeys = append(argDescKeys, key)
+		argDescMap[key] = description
 	}
 
 	// Sort option names, so they are output in alphabetical order.
@@ -52,6 +59,28 @@ func DescribeArguments(arguments []Argument) string {
 	return desc
 }
 
+// DescribeArgument describes a single Argument, as it is shown by DescribeArguments. The name
+// includes an ellipsis for variadic arguments, and the description includes any annotations, e.g.
+// the argument's default value.
+func DescribeArgument(arg Argument) (name string, description string) {
+	name = arg.Name
+
+	if arg.Variadic {
+		name += "..."
+	}
+
+	description = arg.Description
+	if arg.Default != "" {
+		description = strings.TrimSpace(fmt.Sprintf("%s (default: %s)", description, arg.Default))
+	}
+
+	if arg.Deprecated != "" {
+		description = strings.TrimSpace(fmt.Sprintf("%s (deprecated: %s)", description, arg.Deprecated))
+	}
+
+	return name, description
+}
+
 // argumentNameSort allows argument name sorting (trim leading brackets, and alphabetically sort).
 type argumentNameSort []string
 
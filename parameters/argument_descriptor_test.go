@@ -39,6 +39,17 @@ func TestDescribeArguments(t *testing.T) {
 		assert.True(t, strings.Contains(result, "TEST_ARG2"), "Expected argument name in result.")
 	})
 
+	t.Run("should show variadic arguments with an ellipsis", func(t *testing.T) {
+		result := parameters.DescribeArguments([]parameters.Argument{
+			{
+				Name:     "FILES",
+				Variadic: true,
+			},
+		})
+
+		assert.True(t, strings.Contains(result, "FILES..."), "Expected ellipsis in result.")
+	})
+
 	t.Run("should sort arguments into alphabetical order", func(t *testing.T) {
 		result := parameters.DescribeArguments([]parameters.Argument{
 			{
@@ -54,4 +65,49 @@ func TestDescribeArguments(t *testing.T) {
 
 		assert.True(t, fooIdx > barIdx, "Expected FOO to come after BAR.")
 	})
+
+	t.Run("should show default values", func(t *testing.T) {
+		result := parameters.DescribeArguments([]parameters.Argument{
+			{
+				Name:        "NAME",
+				Description: "The name to greet.",
+				Default:     "World",
+			},
+		})
+
+		assert.True(t, strings.Contains(result, "The name to greet. (default: World)"), "Expected default.")
+	})
+
+	t.Run("should not show hidden arguments", func(t *testing.T) {
+		result := parameters.DescribeArguments([]parameters.Argument{
+			{Name: "NAME"},
+			{Name: "EXPERIMENTAL", Hidden: true},
+		})
+
+		assert.True(t, strings.Contains(result, "NAME"), "Expected visible argument.")
+		assert.False(t, strings.Contains(result, "EXPERIMENTAL"), "Expected no hidden argument.")
+	})
+
+	t.Run("should show deprecated arguments as deprecated", func(t *testing.T) {
+		result := parameters.DescribeArguments([]parameters.Argument{
+			{
+				Name:        "USER",
+				Description: "The name to greet.",
+				Deprecated:  "use the --name option instead",
+			},
+		})
+
+		assert.True(t, strings.Contains(result, "The name to greet. (deprecated: use the --name option instead)"), "Expected annotation.")
+	})
+
+	t.Run("should describe single arguments the same way", func(t *testing.T) {
+		name, description := parameters.DescribeArgument(parameters.Argument{
+			Name:        "NAMES",
+			Description: "The names to greet.",
+			Variadic:    true,
+		})
+
+		assert.Equal(t, "NAMES...", name)
+		assert.Equal(t, "The names to greet.", description)
+	})
 }
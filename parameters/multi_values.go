@@ -0,0 +1,274 @@
+package parameters
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MultiValue represents a value that accumulates each value it is given, instead of replacing its
+// value. Options that reference a MultiValue may be given more than once, and every occurrence will
+// be set on the value.
+//
+// Values returns each of the accumulated values, converted to strings. Reset makes the next value
+// given replace whatever is currently referenced, rather than being appended to it.
+type MultiValue interface {
+	Value
+	Values() []string
+	Reset()
+}
+
+// accumulator contains the behaviour shared by the MultiValue implementations.
+type accumulator struct {
+	// The delimiter to split each given value on, if any.
+	delimiter string
+	// Whether or not a value has been given yet.
+	set bool
+}
+
+// split splits the given input on the accumulator's delimiter, if it has one.
+func (a *accumulator) split(s string) []string {
+	if a.delimiter == "" {
+		return []string{s}
+	}
+
+	return strings.Split(s, a.delimiter)
+}
+
+// first reports whether this is the first time a value has been given. Whatever is referenced
+// before then is treated as a default, and is replaced rather than appended to.
+func (a *accumulator) first() bool {
+	first := !a.set
+	a.set = true
+
+	return first
+}
+
+// Reset makes the next value given replace whatever is currently referenced.
+func (a *accumulator) Reset() {
+	a.set = false
+}
+
+// DurationSliceValue abstracts functionality for parsing input that should be represented as a
+// []time.Duration.
+type DurationSliceValue struct {
+	accumulator
+	ref *[]time.Duration
+}
+
+// NewDurationSliceValue creates a new DurationSliceValue.
+func NewDurationSliceValue(ref *[]time.Duration) *DurationSliceValue {
+	return &DurationSliceValue{
+		ref: ref,
+	}
+}
+
+// WithDelimiter sets a delimiter that each given value will be split on.
+func (d *DurationSliceValue) WithDelimiter(delimiter string) *DurationSliceValue {
+	d.delimiter = delimiter
+	return d
+}
+
+// Set appends values to the slice that this DurationSliceValue references.
+func (d *DurationSliceValue) Set(s string) error {
+	var values []time.Duration
+
+	for _, part := range d.split(s) {
+		v, err := time.ParseDuration(part)
+		if err != nil {
+			return err
+		}
+
+		values = append(values, v)
+	}
+
+	if d.first() {
+		*d.ref = nil
+	}
+
+	*d.ref = append(*d.ref, values...)
+
+	return nil
+}
+
+// String converts this DurationSliceValue to a string.
+func (d *DurationSliceValue) String() string {
+	return strings.Join(d.Values(), ",")
+}
+
+// Values returns each of the durations in the slice that this DurationSliceValue references.
+func (d *DurationSliceValue) Values() []string {
+	var values []string
+	for _, v := range *d.ref {
+		values = append(values, v.String())
+	}
+
+	return values
+}
+
+// IntSliceValue abstracts functionality for parsing input that should be represented as an []int.
+type IntSliceValue struct {
+	accumulator
+	ref *[]int
+}
+
+// NewIntSliceValue creates a new IntSliceValue.
+func NewIntSliceValue(ref *[]int) *IntSliceValue {
+	return &IntSliceValue{
+		ref: ref,
+	}
+}
+
+// WithDelimiter sets a delimiter that each given value will be split on.
+func (i *IntSliceValue) WithDelimiter(delimiter string) *IntSliceValue {
+	i.delimiter = delimiter
+	return i
+}
+
+// Set appends values to the slice that this IntSliceValue references.
+func (i *IntSliceValue) Set(s string) error {
+	var values []int
+
+	for _, part := range i.split(s) {
+		v, err := strconv.ParseInt(part, 0, 64)
+		if err != nil {
+			return err
+		}
+
+		values = append(values, int(v))
+	}
+
+	if i.first() {
+		*i.ref = nil
+	}
+
+	*i.ref = append(*i.ref, values...)
+
+	return nil
+}
+
+// String converts this IntSliceValue to a string.
+func (i *IntSliceValue) String() string {
+	return strings.Join(i.Values(), ",")
+}
+
+// Values returns each of the ints in the slice that this IntSliceValue references.
+func (i *IntSliceValue) Values() []string {
+	var values []string
+	for _, v := range *i.ref {
+		values = append(values, strconv.Itoa(v))
+	}
+
+	return values
+}
+
+// StringMapValue abstracts functionality for parsing `key=value` input that should be represented
+// as a map[string]string.
+type StringMapValue struct {
+	accumulator
+	ref *map[string]string
+}
+
+// NewStringMapValue creates a new StringMapValue.
+func NewStringMapValue(ref *map[string]string) *StringMapValue {
+	return &StringMapValue{
+		ref: ref,
+	}
+}
+
+// WithDelimiter sets a delimiter that each given value will be split on.
+func (m *StringMapValue) WithDelimiter(delimiter string) *StringMapValue {
+	m.delimiter = delimiter
+	return m
+}
+
+// Set adds `key=value` pairs to the map that this StringMapValue references.
+func (m *StringMapValue) Set(s string) error {
+	var pairs [][]string
+
+	for _, part := range m.split(s) {
+		pair := strings.SplitN(part, "=", 2)
+		if len(pair) != 2 {
+			return fmt.Errorf("Expected a 'key=value' pair, found '%s'", part)
+		}
+
+		pairs = append(pairs, pair)
+	}
+
+	if m.first() || *m.ref == nil {
+		*m.ref = make(map[string]string)
+	}
+
+	for _, pair := range pairs {
+		(*m.ref)[pair[0]] = pair[1]
+	}
+
+	return nil
+}
+
+// String converts this StringMapValue to a string.
+func (m *StringMapValue) String() string {
+	return strings.Join(m.Values(), ",")
+}
+
+// Values returns each of the `key=value` pairs in the map that this StringMapValue references,
+// sorted by key.
+func (m *StringMapValue) Values() []string {
+	var keys []string
+	for key := range *m.ref {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var values []string
+	for _, key := range keys {
+		values = append(values, key+"="+(*m.ref)[key])
+	}
+
+	return values
+}
+
+// StringSliceValue accepts string input, and transparently appends it to a slice.
+type StringSliceValue struct {
+	accumulator
+	ref *[]string
+}
+
+// NewStringSliceValue creates a new StringSliceValue.
+func NewStringSliceValue(ref *[]string) *StringSliceValue {
+	return &StringSliceValue{
+		ref: ref,
+	}
+}
+
+// WithDelimiter sets a delimiter that each given value will be split on.
+func (s *StringSliceValue) WithDelimiter(delimiter string) *StringSliceValue {
+	s.delimiter = delimiter
+	return s
+}
+
+// Set appends values to the slice that this StringSliceValue references.
+func (s *StringSliceValue) Set(val string) error {
+	values := s.split(val)
+
+	if s.first() {
+		*s.ref = nil
+	}
+
+	*s.ref = append(*s.ref, values...)
+
+	return nil
+}
+
+// String converts this StringSliceValue to a string.
+func (s *StringSliceValue) String() string {
+	return strings.Join(s.Values(), ",")
+}
+
+// Values returns each of the strings in the slice that this StringSliceValue references.
+func (s *StringSliceValue) Values() []string {
+	return append([]string(nil), *s.ref...)
+}
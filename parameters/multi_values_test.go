@@ -0,0 +1,212 @@
+package parameters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eidolon/console/parameters"
+	"github.com/seeruk/assert"
+)
+
+func TestDurationSliceValue(t *testing.T) {
+	t.Run("NewDurationSliceValue()", func(t *testing.T) {
+		ref := []time.Duration{time.Second, time.Minute}
+		value := parameters.NewDurationSliceValue(&ref)
+
+		assert.Equal(t, "1s,1m0s", value.String())
+	})
+
+	t.Run("Set()", func(t *testing.T) {
+		t.Run("should error for invalid values", func(t *testing.T) {
+			var ref []time.Duration
+			value := parameters.NewDurationSliceValue(&ref)
+
+			invalid := []string{
+				"",
+				"1d",
+				"20 decades",
+			}
+
+			for _, item := range invalid {
+				err := value.Set(item)
+				assert.NotOK(t, err)
+			}
+		})
+
+		t.Run("should replace the initial contents of the slice that it references", func(t *testing.T) {
+			ref := []time.Duration{time.Hour}
+			value := parameters.NewDurationSliceValue(&ref)
+
+			value.Set("1s")
+			assert.Equal(t, []time.Duration{time.Second}, ref)
+
+			value.Set("1m")
+			assert.Equal(t, []time.Duration{time.Second, time.Minute}, ref)
+		})
+
+		t.Run("should split values on the delimiter if one is set", func(t *testing.T) {
+			var ref []time.Duration
+			value := parameters.NewDurationSliceValue(&ref).WithDelimiter(",")
+
+			err := value.Set("1s,1m")
+			assert.OK(t, err)
+			assert.Equal(t, []time.Duration{time.Second, time.Minute}, ref)
+		})
+	})
+
+	t.Run("Values()", func(t *testing.T) {
+		ref := []time.Duration{time.Second, time.Minute}
+		value := parameters.NewDurationSliceValue(&ref)
+
+		assert.Equal(t, []string{"1s", "1m0s"}, value.Values())
+	})
+}
+
+func TestIntSliceValue(t *testing.T) {
+	t.Run("NewIntSliceValue()", func(t *testing.T) {
+		ref := []int{1, 2}
+		value := parameters.NewIntSliceValue(&ref)
+
+		assert.Equal(t, "1,2", value.String())
+	})
+
+	t.Run("Set()", func(t *testing.T) {
+		t.Run("should error for invalid values", func(t *testing.T) {
+			var ref []int
+			value := parameters.NewIntSliceValue(&ref)
+
+			invalid := []string{
+				"",
+				"Hello, World!",
+				"3.14",
+			}
+
+			for _, item := range invalid {
+				err := value.Set(item)
+				assert.NotOK(t, err)
+			}
+		})
+
+		t.Run("should replace the initial contents of the slice that it references", func(t *testing.T) {
+			ref := []int{1, 2}
+			value := parameters.NewIntSliceValue(&ref)
+
+			value.Set("3")
+			assert.Equal(t, []int{3}, ref)
+
+			value.Set("4")
+			assert.Equal(t, []int{3, 4}, ref)
+		})
+
+		t.Run("should split values on the delimiter if one is set", func(t *testing.T) {
+			var ref []int
+			value := parameters.NewIntSliceValue(&ref).WithDelimiter(",")
+
+			err := value.Set("1,2,3")
+			assert.OK(t, err)
+			assert.Equal(t, []int{1, 2, 3}, ref)
+
+			err = value.Set("4,five")
+			assert.NotOK(t, err)
+			assert.Equal(t, []int{1, 2, 3}, ref)
+		})
+	})
+
+	t.Run("Values()", func(t *testing.T) {
+		ref := []int{1, 2}
+		value := parameters.NewIntSliceValue(&ref)
+
+		assert.Equal(t, []string{"1", "2"}, value.Values())
+	})
+}
+
+func TestStringMapValue(t *testing.T) {
+	t.Run("NewStringMapValue()", func(t *testing.T) {
+		ref := map[string]string{"foo": "bar", "baz": "qux"}
+		value := parameters.NewStringMapValue(&ref)
+
+		assert.Equal(t, "baz=qux,foo=bar", value.String())
+	})
+
+	t.Run("Set()", func(t *testing.T) {
+		t.Run("should error for invalid values", func(t *testing.T) {
+			var ref map[string]string
+			value := parameters.NewStringMapValue(&ref)
+
+			invalid := []string{
+				"",
+				"foo",
+			}
+
+			for _, item := range invalid {
+				err := value.Set(item)
+				assert.NotOK(t, err)
+			}
+		})
+
+		t.Run("should replace the initial contents of the map that it references", func(t *testing.T) {
+			ref := map[string]string{"foo": "bar"}
+			value := parameters.NewStringMapValue(&ref)
+
+			value.Set("env=production")
+			assert.Equal(t, map[string]string{"env": "production"}, ref)
+
+			value.Set("tier=web=1")
+			assert.Equal(t, map[string]string{"env": "production", "tier": "web=1"}, ref)
+		})
+
+		t.Run("should split values on the delimiter if one is set", func(t *testing.T) {
+			var ref map[string]string
+			value := parameters.NewStringMapValue(&ref).WithDelimiter(",")
+
+			err := value.Set("env=production,tier=web")
+			assert.OK(t, err)
+			assert.Equal(t, map[string]string{"env": "production", "tier": "web"}, ref)
+		})
+	})
+
+	t.Run("Values()", func(t *testing.T) {
+		ref := map[string]string{"foo": "bar", "baz": "qux"}
+		value := parameters.NewStringMapValue(&ref)
+
+		assert.Equal(t, []string{"baz=qux", "foo=bar"}, value.Values())
+	})
+}
+
+func TestStringSliceValue(t *testing.T) {
+	t.Run("NewStringSliceValue()", func(t *testing.T) {
+		ref := []string{"Hello", "World"}
+		value := parameters.NewStringSliceValue(&ref)
+
+		assert.Equal(t, "Hello,World", value.String())
+	})
+
+	t.Run("Set()", func(t *testing.T) {
+		t.Run("should replace the initial contents of the slice that it references", func(t *testing.T) {
+			ref := []string{"Hello"}
+			value := parameters.NewStringSliceValue(&ref)
+
+			value.Set("World")
+			assert.Equal(t, []string{"World"}, ref)
+
+			value.Set("Again")
+			assert.Equal(t, []string{"World", "Again"}, ref)
+		})
+
+		t.Run("should split values on the delimiter if one is set", func(t *testing.T) {
+			var ref []string
+			value := parameters.NewStringSliceValue(&ref).WithDelimiter(",")
+
+			value.Set("foo,bar")
+			value.Set("baz")
+			assert.Equal(t, []string{"foo", "bar", "baz"}, ref)
+		})
+	})
+
+	t.Run("Values()", func(t *testing.T) {
+		ref := []string{"Hello", "World"}
+		value := parameters.NewStringSliceValue(&ref)
+
+		assert.Equal(t, []string{"Hello", "World"}, value.Values())
+	})
+}
@@ -18,11 +18,29 @@ type Option struct {
 	// The description of this option.
 	Description string
 	// The name of an environment variable to read an option value from.
+	//
+	// Deprecated: Use EnvVars instead, this is only the first of them.
 	EnvVar string
+	// The names of environment variables to read an option value from, in order of precedence.
+	EnvVars []string
 	// The value that this option references.
 	Value Value
 	// Does this option take a value? Is it optional, or required?
 	ValueMode OptionValueMode
 	// The name of the value (shown in contextual help).
 	ValueName string
+	// Must this option be given?
+	Required bool
+	// The default value of this option, applied before mapping input.
+	Default string
+	// Is this option left out of contextual help and completion?
+	Hidden bool
+	// If set, the option is deprecated, and this describes what to use instead.
+	Deprecated string
+	// Was this option inherited from an ancestor of the command being run?
+	Inherited bool
+	// Where this option's value was set from when input was last mapped.
+	Source ValueSource
+	// Exactly where this option's value was set from, e.g. the name of an environment variable.
+	Origin string
 }
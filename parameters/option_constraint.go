@@ -0,0 +1,118 @@
+package parameters
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Option constraint kinds.
+const (
+	// Only one of the options may be given. Options given by a lower precedence source than another
+	// of them are overridden rather than given, see ValueSource.
+	ConstraintMutuallyExclusive OptionConstraintKind = iota
+	// If any of the options are given, all of them must be given.
+	ConstraintRequiredTogether
+	// At least one of the options must be given.
+	ConstraintAtLeastOne
+	// If the first option is given, all of the other options must be given too.
+	ConstraintDependsOn
+)
+
+// OptionConstraintKind represents the different kinds of rules that can be placed on options.
+type OptionConstraintKind int
+
+// OptionConstraint provides the internal representation of a rule about which options may, or
+// must, be given together.
+type OptionConstraint struct {
+	// The kind of rule this constraint enforces.
+	Kind OptionConstraintKind
+	// The names of the options this constraint applies to.
+	Names []string
+}
+
+// String describes this constraint in a human readable way.
+func (c OptionConstraint) String() string {
+	switch c.Kind {
+	case ConstraintMutuallyExclusive:
+		return fmt.Sprintf("Options %s are mutually exclusive.", joinOptionNames(c.Names, "and"))
+	case ConstraintRequiredTogether:
+		return fmt.Sprintf("Options %s must be given together.", joinOptionNames(c.Names, "and"))
+	case ConstraintAtLeastOne:
+		return fmt.Sprintf("At least one of %s is required.", joinOptionNames(c.Names, "or"))
+	case ConstraintDependsOn:
+		return fmt.Sprintf(
+			"Option %s requires %s.",
+			joinOptionNames(c.Names[:1], "and"),
+			joinOptionNames(c.Names[1:], "and"),
+		)
+	}
+
+	return ""
+}
+
+// Check checks that the options given satisfy this constraint. The given function reports whether
+// the option with a given name was given. The returned error names the offending options.
+func (c OptionConstraint) Check(given func(name string) bool) error {
+	var present []string
+	var missing []string
+
+	for _, name := range c.Names {
+		if given(name) {
+			present = append(present, name)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+
+	switch c.Kind {
+	case ConstraintMutuallyExclusive:
+		if len(present) > 1 {
+			return fmt.Errorf("Options %s cannot be given together", joinOptionNames(present, "and"))
+		}
+	case ConstraintRequiredTogether:
+		if len(present) > 0 && len(missing) > 0 {
+			return fmt.Errorf(
+				"Options %s must be given together, missing %s",
+				joinOptionNames(c.Names, "and"),
+				joinOptionNames(missing, "and"),
+			)
+		}
+	case ConstraintAtLeastOne:
+		if len(present) == 0 {
+			return fmt.Errorf("At least one of %s is required", joinOptionNames(c.Names, "or"))
+		}
+	case ConstraintDependsOn:
+		if given(c.Names[0]) && len(present) < len(c.Names) {
+			return fmt.Errorf(
+				"Option %s requires %s",
+				joinOptionNames(c.Names[:1], "and"),
+				joinOptionNames(missing, "and"),
+			)
+		}
+	}
+
+	return nil
+}
+
+// joinOptionNames formats the given option names, and joins them into a list using the given
+// conjunction for the last name.
+func joinOptionNames(names []string, conjunction string) string {
+	var formatted []string
+	for _, name := range names {
+		if len(name) > 1 {
+			name = "--" + name
+		} else {
+			name = "-" + name
+		}
+
+		formatted = append(formatted, fmt.Sprintf("'%s'", name))
+	}
+
+	if len(formatted) < 2 {
+		return strings.Join(formatted, "")
+	}
+
+	last := len(formatted) - 1
+
+	return fmt.Sprintf("%s %s %s", strings.Join(formatted[:last], ", "), conjunction, formatted[last])
+}
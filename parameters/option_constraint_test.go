@@ -0,0 +1,94 @@
+package parameters_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eidolon/console/parameters"
+	"github.com/seeruk/assert"
+)
+
+func TestOptionConstraint(t *testing.T) {
+	givenFunc := func(names ...string) func(string) bool {
+		return func(name string) bool {
+			for _, n := range names {
+				if n == name {
+					return true
+				}
+			}
+
+			return false
+		}
+	}
+
+	t.Run("Check()", func(t *testing.T) {
+		t.Run("should check mutually exclusive options", func(t *testing.T) {
+			constraint := parameters.OptionConstraint{
+				Kind:  parameters.ConstraintMutuallyExclusive,
+				Names: []string{"json", "table", "yaml"},
+			}
+
+			assert.OK(t, constraint.Check(givenFunc()))
+			assert.OK(t, constraint.Check(givenFunc("json")))
+
+			err := constraint.Check(givenFunc("json", "yaml"))
+			assert.NotOK(t, err)
+			assert.True(t, strings.Contains(err.Error(), "'--json' and '--yaml'"), "Expected names.")
+			assert.False(t, strings.Contains(err.Error(), "--table"), "Expected no other names.")
+		})
+
+		t.Run("should check options required together", func(t *testing.T) {
+			constraint := parameters.OptionConstraint{
+				Kind:  parameters.ConstraintRequiredTogether,
+				Names: []string{"user", "password"},
+			}
+
+			assert.OK(t, constraint.Check(givenFunc()))
+			assert.OK(t, constraint.Check(givenFunc("user", "password")))
+
+			err := constraint.Check(givenFunc("user"))
+			assert.NotOK(t, err)
+			assert.True(t, strings.Contains(err.Error(), "missing '--password'"), "Expected names.")
+		})
+
+		t.Run("should check that at least one option is given", func(t *testing.T) {
+			constraint := parameters.OptionConstraint{
+				Kind:  parameters.ConstraintAtLeastOne,
+				Names: []string{"token", "u"},
+			}
+
+			assert.OK(t, constraint.Check(givenFunc("token")))
+			assert.OK(t, constraint.Check(givenFunc("u")))
+
+			err := constraint.Check(givenFunc())
+			assert.NotOK(t, err)
+			assert.True(t, strings.Contains(err.Error(), "'--token' or '-u'"), "Expected names.")
+		})
+
+		t.Run("should check option dependencies", func(t *testing.T) {
+			constraint := parameters.OptionConstraint{
+				Kind:  parameters.ConstraintDependsOn,
+				Names: []string{"password", "user", "host"},
+			}
+
+			assert.OK(t, constraint.Check(givenFunc()))
+			assert.OK(t, constraint.Check(givenFunc("user")))
+			assert.OK(t, constraint.Check(givenFunc("password", "user", "host")))
+
+			err := constraint.Check(givenFunc("password", "host"))
+			assert.NotOK(t, err)
+			assert.True(t, strings.Contains(err.Error(), "'--password' requires '--user'"), "Expected names.")
+		})
+	})
+
+	t.Run("String()", func(t *testing.T) {
+		constraint := parameters.OptionConstraint{
+			Kind:  parameters.ConstraintMutuallyExclusive,
+			Names: []string{"json", "table", "yaml"},
+		}
+
+		expected := "Options '--json', '--table' and '--yaml' are mutually exclusive."
+
+		assert.Equal(t, expected, constraint.String())
+	})
+}
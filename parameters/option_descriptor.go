@@ -8,9 +8,71 @@ import (
 	"github.com/eidolon/wordwrap"
 )
 
-// DescribeOptions describes an array of Options, formatting them in a helpful way.
-func DescribeOptions(options []Option) string {
-	desc := "OPTIONS:\n"
+// DescribeOptions describes an array of Options, formatting them in a helpful way. Any constraints
+// given are described after the options. Hidden options are not described.
+func DescribeOptions(options []Option, constraints ...OptionConstraint) string {
+	return describeOptions("OPTIONS", options, constraints)
+}
+
+// DescribeInheritedOptions describes an array of Options inherited from parent commands, the same
+// way that DescribeOptions does, under it's own title.
+func DescribeInheritedOptions(options []Option, constraints ...OptionConstraint) string {
+	return describeOptions("INHERITED OPTIONS", options, constraints)
+}
+
+// DescribeOption describes a single Option, as it is shown by DescribeOptions. The names include
+// the value name, e.g. `-n, --name=NAME`, and the description includes any annotations, e.g. the
+// option's default value.
+func DescribeOption(opt Option) (names string, description string) {
+	var formatted []string
+	for _, name := range opt.Names {
+		if len(name) > 1 {
+			name = "--" + name
+		} else {
+			name = "-" + name
+		}
+
+		formatted = append(formatted, name)
+	}
+
+	// Sort the names so that short names appear first in the output.
+	sort.Sort(stringLengthSort(formatted))
+
+	// Join the names into on comma-separated string.
+	names = strings.Join(formatted, ", ")
+
+	// Describe option value
+	if opt.ValueMode == OptionValueOptional {
+		names += "["
+	}
+
+	if opt.ValueMode == OptionValueOptional || opt.ValueMode == OptionValueRequired {
+		names += "=" + opt.ValueName
+	}
+
+	if opt.ValueMode == OptionValueOptional {
+		names += "]"
+	}
+
+	description = opt.Description
+	if opt.Required {
+		description = strings.TrimSpace(description + " (required)")
+	}
+
+	if opt.Default != "" {
+		description = strings.TrimSpace(fmt.Sprintf("%s (default: %s)", description, opt.Default))
+	}
+
+	if opt.Deprecated != "" {
+		description = strings.TrimSpace(fmt.Sprintf("%s (deprecated: %s)", description, opt.Deprecated))
+	}
+
+	return names, description
+}
+
+// describeOptions describes an array of Options, and any constraints, under the given title.
+func describeOptions(title string, options []Option, constraints []OptionConstraint) string {
+	desc := title + ":\n"
 
 	// Create array and map for specific output ordering
 	optDescKeys := []string{}
@@ -19,38 +81,14 @@ func DescribeOptions(options []Option) string {
 	// Generate the list of names, so that output can be formatted correctly, and in the correct
 	// order (i.e. alphabetical), and with sorted names for each option individually.
 	for _, opt := range options {
-		var names []string
-		for _, name := range opt.Names {
-			if len(name) > 1 {
-				name = "--" + name
-			} else {
-				name = "-" + name
-			}
-
-			names = append(names, name)
-		}
-
-		// Sort the names so that short names appear first in the output.
-		sort.Sort(stringLengthSort(names))
-
-		// Join the names into on comma-separated string.
-		key := strings.Join(names, ", ")
-
-		// Describe option value
-		if opt.ValueMode == OptionValueOptional {
-			key += "["
+		if opt.Hidden {
+			continue
 		}
 
-		if opt.ValueMode == OptionValueOptional || opt.ValueMode == OptionValueRequired {
-			key += "=" + opt.ValueName
-		}
-
-		if opt.ValueMode == OptionValueOptional {
-			key += "]"
-		}
+		key, description := DescribeOption(opt)
 
 		optDescKeys = append(optDescKeys, key)
-		optDescMap[key] = opt.Description
+		optDescMap[key] = description
 	}
 
 	// Sort option names, so they are output in alphabetical order.
@@ -80,6 +118,16 @@ func DescribeOptions(options []Option) string {
 		desc += wordwrap.Indent(wrapped, prefix, false) + "\n"
 	}
 
+	if len(constraints) > 0 {
+		desc += "\n"
+	}
+
+	for _, constraint := range constraints {
+		wrapper := wordwrap.Wrapper(76, true)
+
+		desc += wordwrap.Indent(wrapper(constraint.String()), "  ", true) + "\n"
+	}
+
 	return desc
 }
 
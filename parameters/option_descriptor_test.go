@@ -128,4 +128,89 @@ func TestDescribeOptions(t *testing.T) {
 
 		assert.True(t, fIdx > bIdx, "Expected -f to come after -b.")
 	})
+
+	t.Run("should describe constraints if there are any", func(t *testing.T) {
+		result := parameters.DescribeOptions(
+			[]parameters.Option{
+				{Names: []string{"json"}},
+				{Names: []string{"table"}},
+			},
+			parameters.OptionConstraint{
+				Kind:  parameters.ConstraintMutuallyExclusive,
+				Names: []string{"json", "table"},
+			},
+		)
+
+		expected := "Options '--json' and '--table' are mutually exclusive."
+
+		assert.True(t, strings.Contains(result, expected), "Expected constraint in output.")
+	})
+
+	t.Run("should show required options as required", func(t *testing.T) {
+		result := parameters.DescribeOptions([]parameters.Option{
+			{
+				Names:       []string{"cluster"},
+				Description: "The cluster to use.",
+				Required:    true,
+			},
+		})
+
+		assert.True(t, strings.Contains(result, "The cluster to use. (required)"), "Expected annotation.")
+	})
+
+	t.Run("should show default values", func(t *testing.T) {
+		result := parameters.DescribeOptions([]parameters.Option{
+			{
+				Names:       []string{"name"},
+				Description: "The name to greet.",
+				Default:     "World",
+			},
+		})
+
+		assert.True(t, strings.Contains(result, "The name to greet. (default: World)"), "Expected default.")
+	})
+
+	t.Run("should not show hidden options", func(t *testing.T) {
+		result := parameters.DescribeOptions([]parameters.Option{
+			{Names: []string{"name"}},
+			{Names: []string{"experimental"}, Hidden: true},
+		})
+
+		assert.True(t, strings.Contains(result, "--name"), "Expected visible option.")
+		assert.False(t, strings.Contains(result, "--experimental"), "Expected no hidden option.")
+	})
+
+	t.Run("should show deprecated options as deprecated", func(t *testing.T) {
+		result := parameters.DescribeOptions([]parameters.Option{
+			{
+				Names:       []string{"user"},
+				Description: "The name to greet.",
+				Deprecated:  "use --name instead",
+			},
+		})
+
+		assert.True(t, strings.Contains(result, "The name to greet. (deprecated: use --name instead)"), "Expected annotation.")
+	})
+
+	t.Run("should include a title for inherited options", func(t *testing.T) {
+		result := parameters.DescribeInheritedOptions([]parameters.Option{
+			{Names: []string{"context"}},
+		})
+
+		assert.True(t, strings.HasPrefix(result, "INHERITED OPTIONS:\n"), "Expected a title.")
+		assert.True(t, strings.Contains(result, "--context"), "Expected option name in result.")
+	})
+
+	t.Run("should describe single options the same way", func(t *testing.T) {
+		names, description := parameters.DescribeOption(parameters.Option{
+			Names:       []string{"name", "n"},
+			Description: "The name to greet.",
+			ValueMode:   parameters.OptionValueRequired,
+			ValueName:   "NAME",
+			Default:     "World",
+		})
+
+		assert.Equal(t, "-n, --name=NAME", names)
+		assert.Equal(t, "The name to greet. (default: World)", description)
+	})
 }
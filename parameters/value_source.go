@@ -0,0 +1,35 @@
+package parameters
+
+// Value sources, in order of increasing precedence.
+const (
+	// The value wasn't set, and has no default.
+	SourceNone ValueSource = iota
+	// The value was set from a default value.
+	SourceDefault
+	// The value was set from a config file.
+	SourceConfig
+	// The value was set from an environment variable.
+	SourceEnv
+	// The value was set from input given on the command line.
+	SourceInput
+)
+
+// ValueSource represents where the value of an option or argument was set from. Values from a
+// source with a higher precedence are never replaced by values from one with a lower precedence.
+type ValueSource int
+
+// String gets the name of this source.
+func (s ValueSource) String() string {
+	switch s {
+	case SourceDefault:
+		return "default"
+	case SourceConfig:
+		return "config"
+	case SourceEnv:
+		return "env"
+	case SourceInput:
+		return "input"
+	}
+
+	return "none"
+}
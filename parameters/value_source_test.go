@@ -0,0 +1,26 @@
+package parameters_test
+
+import (
+	"testing"
+
+	"github.com/eidolon/console/parameters"
+	"github.com/seeruk/assert"
+)
+
+func TestValueSource(t *testing.T) {
+	t.Run("String()", func(t *testing.T) {
+		t.Run("should name each source", func(t *testing.T) {
+			assert.Equal(t, "none", parameters.SourceNone.String())
+			assert.Equal(t, "default", parameters.SourceDefault.String())
+			assert.Equal(t, "config", parameters.SourceConfig.String())
+			assert.Equal(t, "env", parameters.SourceEnv.String())
+			assert.Equal(t, "input", parameters.SourceInput.String())
+		})
+	})
+
+	t.Run("should be ordered by precedence", func(t *testing.T) {
+		assert.True(t, parameters.SourceInput > parameters.SourceEnv, "Expected input over env.")
+		assert.True(t, parameters.SourceEnv > parameters.SourceConfig, "Expected env over config.")
+		assert.True(t, parameters.SourceConfig > parameters.SourceDefault, "Expected config over default.")
+	})
+}
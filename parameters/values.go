@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -23,6 +26,12 @@ type FlagValue interface {
 	FlagValue() string
 }
 
+// Completer represents a value that can suggest candidates for shell completion. The prefix is
+// whatever the user has typed so far, and may be empty.
+type Completer interface {
+	Complete(prefix string) []string
+}
+
 // BoolValue abstracts functionality for parsing input that should be represented as a boolean. The
 // BoolValue type also implements the FlagValue interface so that an alternative to the default
 // value can be used if no value is present.
@@ -91,6 +100,51 @@ func (d *DurationValue) String() string {
 	return (*time.Duration)(d).String()
 }
 
+// EnumValue abstracts functionality for parsing input that should be one of a fixed set of strings.
+// The EnumValue type also implements the Completer interface, suggesting the allowed choices.
+type EnumValue struct {
+	ref     *string
+	choices []string
+}
+
+// NewEnumValue creates a new EnumValue.
+func NewEnumValue(ref *string, choices ...string) *EnumValue {
+	return &EnumValue{
+		ref:     ref,
+		choices: choices,
+	}
+}
+
+// Set assigns a value to the value that this EnumValue references.
+func (e *EnumValue) Set(s string) error {
+	for _, choice := range e.choices {
+		if s == choice {
+			*e.ref = s
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Expected one of '%s'", strings.Join(e.choices, "', '"))
+}
+
+// String converts this EnumValue to a string.
+func (e *EnumValue) String() string {
+	return *e.ref
+}
+
+// Complete returns the choices that begin with the given prefix.
+func (e *EnumValue) Complete(prefix string) []string {
+	var candidates []string
+
+	for _, choice := range e.choices {
+		if strings.HasPrefix(choice, prefix) {
+			candidates = append(candidates, choice)
+		}
+	}
+
+	return candidates
+}
+
 // Float32Value abstracts functionality for parsing input that should be represented as a float32.
 type Float32Value float32
 
@@ -182,6 +236,63 @@ func (s *IPValue) String() string {
 	return ip.String()
 }
 
+// PathValue accepts a path to a file or directory, and transparently assigns it to a pointer. The
+// PathValue type also implements the Completer interface, suggesting the entries of the directory
+// that has been typed so far.
+type PathValue string
+
+// NewPathValue creates a new PathValue.
+func NewPathValue(ref *string) *PathValue {
+	return (*PathValue)(ref)
+}
+
+// Set assigns a value to the value that this PathValue references.
+func (p *PathValue) Set(val string) error {
+	*p = PathValue(val)
+	return nil
+}
+
+// String converts this PathValue to a string.
+func (p *PathValue) String() string {
+	return string(*p)
+}
+
+// Complete returns the entries of the directory in the given prefix whose names begin with the
+// rest of the prefix. Directories end with a separator, so that they can be completed into. Hidden
+// entries are only returned if the prefix asks for them.
+func (p *PathValue) Complete(prefix string) []string {
+	dir, base := filepath.Split(prefix)
+
+	readDir := dir
+	if readDir == "" {
+		readDir = "."
+	}
+
+	entries, err := os.ReadDir(readDir)
+	if err != nil {
+		return nil
+	}
+
+	var candidates []string
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		if !strings.HasPrefix(name, base) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".")) {
+			continue
+		}
+
+		candidate := dir + name
+		if entry.IsDir() {
+			candidate += string(filepath.Separator)
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates
+}
+
 // StringValue accepts string input, and transparently assigns it to a pointer.
 type StringValue string
 
@@ -3,6 +3,8 @@ package parameters_test
 import (
 	"net"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -267,6 +269,69 @@ func TestDurationValue(t *testing.T) {
 	})
 }
 
+func TestEnumValue(t *testing.T) {
+	t.Run("NewEnumValue()", func(t *testing.T) {
+		ref := "json"
+		enumValue := parameters.NewEnumValue(&ref, "json", "table")
+
+		assert.Equal(t, "json", enumValue.String())
+	})
+
+	t.Run("Set()", func(t *testing.T) {
+		t.Run("should not error for valid values", func(t *testing.T) {
+			var ref string
+			value := parameters.NewEnumValue(&ref, "json", "table", "yaml")
+
+			valid := []string{
+				"json",
+				"table",
+				"yaml",
+			}
+
+			for _, item := range valid {
+				err := value.Set(item)
+				assert.OK(t, err)
+			}
+		})
+
+		t.Run("should error for invalid values", func(t *testing.T) {
+			var ref string
+			value := parameters.NewEnumValue(&ref, "json", "table", "yaml")
+
+			invalid := []string{
+				"",
+				"JSON",
+				"xml",
+			}
+
+			for _, item := range invalid {
+				err := value.Set(item)
+				assert.NotOK(t, err)
+			}
+		})
+
+		t.Run("should modify the string that it references", func(t *testing.T) {
+			ref := "json"
+			value := parameters.NewEnumValue(&ref, "json", "table")
+
+			value.Set("table")
+			assert.Equal(t, "table", ref)
+
+			value.Set("xml")
+			assert.Equal(t, "table", ref)
+		})
+	})
+
+	t.Run("Complete()", func(t *testing.T) {
+		var ref string
+		value := parameters.NewEnumValue(&ref, "json", "table", "tsv")
+
+		assert.Equal(t, []string{"json", "table", "tsv"}, value.Complete(""))
+		assert.Equal(t, []string{"table", "tsv"}, value.Complete("t"))
+		assert.Equal(t, 0, len(value.Complete("x")))
+	})
+}
+
 func TestFloat32Value(t *testing.T) {
 	t.Run("NewFloat32Value()", func(t *testing.T) {
 		float := float32(3.14)
@@ -558,6 +623,58 @@ func TestIPValue(t *testing.T) {
 	})
 }
 
+func TestPathValue(t *testing.T) {
+	t.Run("NewPathValue()", func(t *testing.T) {
+		ref := "config.yaml"
+		pathValue := parameters.NewPathValue(&ref)
+
+		assert.Equal(t, "config.yaml", pathValue.String())
+	})
+
+	t.Run("Set()", func(t *testing.T) {
+		t.Run("should modify the string that it references", func(t *testing.T) {
+			var ref string
+			value := parameters.NewPathValue(&ref)
+
+			err := value.Set("/tmp/config.yaml")
+			assert.OK(t, err)
+			assert.Equal(t, "/tmp/config.yaml", ref)
+		})
+	})
+
+	t.Run("Complete()", func(t *testing.T) {
+		dir := t.TempDir()
+
+		for _, name := range []string{"config.yaml", "config.toml", ".hidden", "other.json"} {
+			err := os.WriteFile(filepath.Join(dir, name), nil, 0644)
+			assert.OK(t, err)
+		}
+
+		err := os.Mkdir(filepath.Join(dir, "conf.d"), 0755)
+		assert.OK(t, err)
+
+		var ref string
+		value := parameters.NewPathValue(&ref)
+
+		sep := string(filepath.Separator)
+		prefix := dir + sep
+
+		t.Run("should suggest entries that begin with the prefix", func(t *testing.T) {
+			expected := []string{prefix + "conf.d" + sep, prefix + "config.toml", prefix + "config.yaml"}
+			assert.Equal(t, expected, value.Complete(prefix+"conf"))
+		})
+
+		t.Run("should only suggest hidden entries if asked for", func(t *testing.T) {
+			assert.Equal(t, 4, len(value.Complete(prefix)))
+			assert.Equal(t, []string{prefix + ".hidden"}, value.Complete(prefix+"."))
+		})
+
+		t.Run("should suggest nothing if the directory can't be read", func(t *testing.T) {
+			assert.Equal(t, 0, len(value.Complete(filepath.Join(dir, "missing")+sep)))
+		})
+	})
+}
+
 func TestStringValue(t *testing.T) {
 	t.Run("NewStringValue()", func(t *testing.T) {
 		expected := "Hello, World!"
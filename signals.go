@@ -0,0 +1,90 @@
+package console
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// signalHandler cancels a context when the application receives SIGINT or SIGTERM, so that running
+// commands have the chance to clean up. A second signal forces the application to exit.
+type signalHandler struct {
+	// Channel that signals are delivered on.
+	signals chan os.Signal
+	// Channel closed to stop handling signals.
+	done chan struct{}
+	// Function to cancel the context given to commands.
+	cancel context.CancelFunc
+
+	// The first signal received, if any.
+	sig os.Signal
+	mu  sync.Mutex
+}
+
+// handleSignals creates a context derived from parent that is cancelled when the application
+// receives SIGINT or SIGTERM. The returned handler must be stopped once the command has finished.
+func handleSignals(parent context.Context) (context.Context, *signalHandler) {
+	ctx, cancel := context.WithCancel(parent)
+
+	handler := &signalHandler{
+		signals: make(chan os.Signal, 2),
+		done:    make(chan struct{}),
+		cancel:  cancel,
+	}
+
+	signal.Notify(handler.signals, os.Interrupt, syscall.SIGTERM)
+
+	go handler.loop()
+
+	return ctx, handler
+}
+
+// loop waits for signals until the handler is stopped.
+func (h *signalHandler) loop() {
+	for {
+		select {
+		case sig := <-h.signals:
+			h.mu.Lock()
+			first := h.sig == nil
+			if first {
+				h.sig = sig
+			}
+			h.mu.Unlock()
+
+			if !first {
+				os.Exit(signalExitCode(sig))
+			}
+
+			h.cancel()
+		case <-h.done:
+			return
+		}
+	}
+}
+
+// received gets the first signal the application received, if any.
+func (h *signalHandler) received() os.Signal {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.sig
+}
+
+// stop stops handling signals, and cancels the context.
+func (h *signalHandler) stop() {
+	signal.Stop(h.signals)
+	close(h.done)
+	h.cancel()
+}
+
+// signalExitCode gets the conventional exit code for a process terminated by the given signal, i.e.
+// 128 plus the signal number, e.g. 130 for SIGINT, and 143 for SIGTERM.
+func signalExitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return 128 + int(s)
+	}
+
+	return 1
+}
@@ -10,6 +10,7 @@ import (
 func ParseArgumentSpecification(spec string) (parameters.Argument, error) {
 	scanner := NewScanner(strings.NewReader(spec))
 	parser := newArgumentSpecifcationParser(scanner)
+	parser.spec = spec
 
 	return parser.parse()
 }
@@ -48,6 +49,12 @@ func (p *argumentSpecifcationParser) parse() (parameters.Argument, error) {
 		return argument, p.expected("identifier", lit)
 	}
 
+	if tok, _ := p.scan(); tok == ELLIPSIS {
+		argument.Variadic = true
+	} else {
+		p.unscan()
+	}
+
 	if deep {
 		if tok, lit := p.scan(); tok != RBRACK {
 			return argument, p.expected("closing bracket", lit)
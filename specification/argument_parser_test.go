@@ -24,6 +24,35 @@ func TestParseArgumentSpecification(t *testing.T) {
 		assert.Equal(t, false, argument.Required)
 	})
 
+	t.Run("should set whether or not the argument is variadic", func(t *testing.T) {
+		argument, err := specification.ParseArgumentSpecification("GALAXY_QUEST")
+		assert.OK(t, err)
+		assert.Equal(t, false, argument.Variadic)
+
+		argument, err = specification.ParseArgumentSpecification("FILES...")
+		assert.OK(t, err)
+		assert.Equal(t, "FILES", argument.Name)
+		assert.Equal(t, true, argument.Variadic)
+		assert.Equal(t, true, argument.Required)
+
+		argument, err = specification.ParseArgumentSpecification("[FILES...]")
+		assert.OK(t, err)
+		assert.Equal(t, "FILES", argument.Name)
+		assert.Equal(t, true, argument.Variadic)
+		assert.Equal(t, false, argument.Required)
+	})
+
+	t.Run("should expect the ellipsis inside brackets", func(t *testing.T) {
+		_, err := specification.ParseArgumentSpecification("[FILES]...")
+		assert.NotOK(t, err)
+
+		_, err = specification.ParseArgumentSpecification("FILES..")
+		assert.NotOK(t, err)
+
+		_, err = specification.ParseArgumentSpecification("...")
+		assert.NotOK(t, err)
+	})
+
 	t.Run("should expect a close bracket if an opening one is given", func(t *testing.T) {
 		_, err := specification.ParseArgumentSpecification("[MEMENTO")
 		assert.NotOK(t, err)
@@ -41,4 +70,14 @@ func TestParseArgumentSpecification(t *testing.T) {
 		_, err = specification.ParseArgumentSpecification("[]")
 		assert.NotOK(t, err)
 	})
+
+	t.Run("should return syntax errors that include the spec", func(t *testing.T) {
+		_, err := specification.ParseArgumentSpecification("[GALAXY_QUEST")
+		assert.NotOK(t, err)
+
+		serr, ok := err.(*specification.SyntaxError)
+		assert.True(t, ok, "Expected a *specification.SyntaxError.")
+		assert.Equal(t, "[GALAXY_QUEST", serr.Spec)
+		assert.Equal(t, "specification: Expected closing bracket, found ''", serr.Error())
+	})
 }
@@ -10,6 +10,7 @@ import (
 func ParseOptionSpecification(spec string) (parameters.Option, error) {
 	scanner := NewScanner(strings.NewReader(spec))
 	parser := newOptionSpecifcationParser(scanner)
+	parser.spec = spec
 
 	return parser.parse()
 }
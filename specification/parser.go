@@ -4,6 +4,8 @@ import "fmt"
 
 // Parser provides a base implementation for parsing parameter specifications.
 type parser struct {
+	// The specification being parsed, used in errors.
+	spec string
 	// The token scanner.
 	scanner *Scanner
 	// Parser context.
@@ -47,15 +49,37 @@ func (p *parser) unscan() {
 
 // expectedButActual is a helper for creating parser errors with an expected and actual value.
 func (p *parser) expected(expected string, actual string) error {
-	return fmt.Errorf("specification: Expected %s, found '%s'", expected, actual)
+	return &SyntaxError{
+		Spec:    p.spec,
+		Found:   actual,
+		Message: fmt.Sprintf("Expected %s, found '%s'", expected, actual),
+	}
 }
 
 // expectedLen is a helper for creating parser errors for string lengths.
 func (p *parser) expectedLen(expected string, expectedLen int, actualLen int) error {
-	return fmt.Errorf(
-		"specification: Expected %s to be %d character(s), was %d character(s)",
-		expected,
-		expectedLen,
-		actualLen,
-	)
+	return &SyntaxError{
+		Spec: p.spec,
+		Message: fmt.Sprintf(
+			"Expected %s to be %d character(s), was %d character(s)",
+			expected,
+			expectedLen,
+			actualLen,
+		),
+	}
+}
+
+// SyntaxError is returned when a parameter specification can't be parsed.
+type SyntaxError struct {
+	// The specification that was being parsed.
+	Spec string
+	// The token found where something else was expected, if any.
+	Found string
+	// A description of the problem.
+	Message string
+}
+
+// Error describes the problem with the specification.
+func (e *SyntaxError) Error() string {
+	return "specification: " + e.Message
 }
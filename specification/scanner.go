@@ -24,6 +24,7 @@ const (
 	HYPHEN     // -
 	WS         // Whitespace
 	IDENTIFIER // A-z_-
+	ELLIPSIS   // ...
 )
 
 // Scanner is a lexical scanner for parameter specifications.
@@ -55,6 +56,10 @@ func (s *Scanner) Scan() (Token, string) {
 		return EQUALS, string(r)
 	case '-':
 		return HYPHEN, string(r)
+	case '.':
+		s.unread()
+
+		return s.scanEllipsis()
 	}
 
 	if isWhitespace(r) {
@@ -90,6 +95,27 @@ func (s *Scanner) scanIdentifier() (Token, string) {
 	return IDENTIFIER, buf.String()
 }
 
+// scanEllipsis consumes the current rune and up to two more contiguous periods. Anything other than
+// exactly three periods is illegal.
+func (s *Scanner) scanEllipsis() (Token, string) {
+	var buf bytes.Buffer
+
+	buf.WriteRune(s.read())
+
+	for buf.Len() < 3 {
+		if ch := s.read(); ch == eof {
+			return ILLEGAL, buf.String()
+		} else if ch != '.' {
+			s.unread()
+			return ILLEGAL, buf.String()
+		} else {
+			buf.WriteRune(ch)
+		}
+	}
+
+	return ELLIPSIS, buf.String()
+}
+
 // scanWhitespace consumes the current rune and all contiguous whitespace.
 func (s *Scanner) scanWhitespace() (Token, string) {
 	var buf bytes.Buffer
@@ -71,6 +71,36 @@ func TestScanner(t *testing.T) {
 			assert.Equal(t, "-", val)
 		})
 
+		t.Run("should be able to scan ellipses (...)", func(t *testing.T) {
+			scanner := createScanner("...")
+
+			tok, val := scanner.Scan()
+			assert.Equal(t, specification.ELLIPSIS, tok)
+			assert.Equal(t, "...", val)
+		})
+
+		t.Run("should only scan ellipses made of exactly three periods", func(t *testing.T) {
+			scanner := createScanner("..]")
+
+			tok, val := scanner.Scan()
+			assert.Equal(t, specification.ILLEGAL, tok)
+			assert.Equal(t, "..", val)
+
+			tok, val = scanner.Scan()
+			assert.Equal(t, specification.RBRACK, tok)
+			assert.Equal(t, "]", val)
+
+			scanner = createScanner("....")
+
+			tok, val = scanner.Scan()
+			assert.Equal(t, specification.ELLIPSIS, tok)
+			assert.Equal(t, "...", val)
+
+			tok, val = scanner.Scan()
+			assert.Equal(t, specification.ILLEGAL, tok)
+			assert.Equal(t, ".", val)
+		})
+
 		t.Run("should be able to scan whitespace", func(t *testing.T) {
 			scanner := createScanner(" 	")
 
@@ -0,0 +1,9 @@
+// Package style contains a small markup language for styling terminal output, e.g.
+// `<info>done</info>`, rendered as ANSI escape sequences, or stripped for writers that aren't
+// terminals.
+//
+// Tags name a style, and are closed by `</name>`, or `</>` for the most recently opened tag. Tags
+// may be nested. Links are written as `<href=https://example.com>text</>`, and rendered as OSC 8
+// hyperlinks. Tags that don't name a style are left as they are, and `\<` may be used to write a
+// literal `<` that would otherwise start a tag.
+package style
@@ -0,0 +1,188 @@
+package style
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// reset is the ANSI escape sequence that resets all styles.
+	reset = "\x1b[0m"
+	// linkStart and linkEnd surround the URL of an OSC 8 hyperlink.
+	linkStart = "\x1b]8;;"
+	linkEnd   = "\x1b\\"
+	// hrefPrefix starts the tags of links.
+	hrefPrefix = "href="
+)
+
+// Renderer renders markup, using the styles it knows about.
+type Renderer struct {
+	styles map[string]Style
+}
+
+// NewRenderer creates a new Renderer that knows about the built-in styles.
+func NewRenderer() *Renderer {
+	return &Renderer{
+		styles: Builtins(),
+	}
+}
+
+// AddStyle makes a style available to markup, by the given name. Built-in styles may be replaced.
+// Panics if the style isn't valid, or the name couldn't be used in a tag.
+func (r *Renderer) AddStyle(name string, style Style) {
+	if name == "" || strings.ContainsAny(name, "<>/\\= \t\n") {
+		panic(fmt.Errorf("console: Invalid style name '%s'", name))
+	}
+
+	if err := style.Validate(); err != nil {
+		panic(fmt.Errorf("console: Invalid style '%s': '%w'", name, err))
+	}
+
+	r.styles[name] = style
+}
+
+// Render renders the markup in the given text. If ansi is true, styles and links are rendered as
+// ANSI escape sequences, otherwise they're stripped. Tags left open are closed at the end of the
+// text.
+func (r *Renderer) Render(text string, ansi bool) string {
+	state := markupState{ansi: ansi}
+
+	for i := 0; i < len(text); {
+		if strings.HasPrefix(text[i:], `\<`) {
+			state.out.WriteByte('<')
+			i += 2
+			continue
+		}
+
+		if text[i] == '<' {
+			if end := strings.IndexByte(text[i:], '>'); end > 0 && r.tag(&state, text[i+1:i+end]) {
+				i += end + 1
+				continue
+			}
+		}
+
+		state.out.WriteByte(text[i])
+		i++
+	}
+
+	if len(state.stack) > 0 {
+		state.close(0)
+	}
+
+	return state.out.String()
+}
+
+// tag handles the tag with the given content, returning false if it isn't a tag that is known.
+func (r *Renderer) tag(state *markupState, content string) bool {
+	if strings.HasPrefix(content, "/") {
+		index := state.find(content[1:])
+		if index < 0 {
+			return false
+		}
+
+		state.close(index)
+		return true
+	}
+
+	if strings.HasPrefix(content, hrefPrefix) {
+		href := content[len(hrefPrefix):]
+		if href == "" || strings.ContainsAny(href, " \t\n") {
+			return false
+		}
+
+		state.open(markupTag{name: "href", href: href})
+		return true
+	}
+
+	style, ok := r.styles[content]
+	if !ok {
+		return false
+	}
+
+	state.open(markupTag{name: content, style: style})
+	return true
+}
+
+// markupTag is a tag that has been opened, i.e. either a style, or a link.
+type markupTag struct {
+	name  string
+	style Style
+	href  string
+}
+
+// markupState is the state of rendering some markup.
+type markupState struct {
+	ansi  bool
+	out   strings.Builder
+	stack []markupTag
+}
+
+// open opens the given tag.
+func (s *markupState) open(tag markupTag) {
+	s.stack = append(s.stack, tag)
+
+	if !s.ansi {
+		return
+	}
+
+	if tag.href != "" {
+		s.out.WriteString(linkStart + tag.href + linkEnd)
+	} else {
+		s.out.WriteString(tag.style.sequence())
+	}
+}
+
+// find finds the index of the most recently opened tag with the given name, or the most recently
+// opened tag if the name is empty. Returns -1 if there is no such tag.
+func (s *markupState) find(name string) int {
+	for i := len(s.stack) - 1; i >= 0; i-- {
+		if name == "" || s.stack[i].name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// close closes the tag at the given index in the stack, and every tag opened after it. The styles
+// and link of the tags that remain open are re-applied.
+func (s *markupState) close(index int) {
+	closed := s.stack[index:]
+	s.stack = s.stack[:index]
+
+	if !s.ansi {
+		return
+	}
+
+	var closedStyle, closedLink bool
+	for _, tag := range closed {
+		closedLink = closedLink || tag.href != ""
+		closedStyle = closedStyle || tag.href == ""
+	}
+
+	if closedLink {
+		s.out.WriteString(linkStart + linkEnd)
+
+		for i := len(s.stack) - 1; i >= 0; i-- {
+			if s.stack[i].href != "" {
+				s.out.WriteString(linkStart + s.stack[i].href + linkEnd)
+				break
+			}
+		}
+	}
+
+	if closedStyle {
+		s.out.WriteString(reset)
+
+		for _, tag := range s.stack {
+			if tag.href == "" {
+				s.out.WriteString(tag.style.sequence())
+			}
+		}
+	}
+}
+
+// Escape escapes the given text, so that it's written as it is, even if it contains tags.
+func Escape(text string) string {
+	return strings.ReplaceAll(text, "<", `\<`)
+}
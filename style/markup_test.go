@@ -0,0 +1,95 @@
+package style_test
+
+import (
+	"testing"
+
+	"github.com/eidolon/console/style"
+	"github.com/seeruk/assert"
+)
+
+func TestRenderer(t *testing.T) {
+	t.Run("Render()", func(t *testing.T) {
+		t.Run("should render styles as ANSI escape sequences", func(t *testing.T) {
+			renderer := style.NewRenderer()
+
+			actual := renderer.Render("<info>done</info>, <error>failed</>", true)
+			expected := "\x1b[32mdone\x1b[0m, \x1b[37;41mfailed\x1b[0m"
+
+			assert.Equal(t, expected, actual)
+		})
+
+		t.Run("should re-apply outer styles when nested styles are closed", func(t *testing.T) {
+			renderer := style.NewRenderer()
+
+			actual := renderer.Render("<info>a <b>b</b> c</info>", true)
+			expected := "\x1b[32ma \x1b[1mb\x1b[0m\x1b[32m c\x1b[0m"
+
+			assert.Equal(t, expected, actual)
+		})
+
+		t.Run("should render links as OSC 8 hyperlinks", func(t *testing.T) {
+			renderer := style.NewRenderer()
+
+			actual := renderer.Render("see <href=https://example.com>the docs</>", true)
+			expected := "see \x1b]8;;https://example.com\x1b\\the docs\x1b]8;;\x1b\\"
+
+			assert.Equal(t, expected, actual)
+		})
+
+		t.Run("should close tags left open", func(t *testing.T) {
+			renderer := style.NewRenderer()
+
+			actual := renderer.Render("<comment>unclosed", true)
+			assert.Equal(t, "\x1b[33munclosed\x1b[0m", actual)
+		})
+
+		t.Run("should strip styles and links if ANSI is disabled", func(t *testing.T) {
+			renderer := style.NewRenderer()
+
+			actual := renderer.Render("<info>a <b>b</b></info> <href=https://example.com>c</>", false)
+			assert.Equal(t, "a b c", actual)
+		})
+
+		t.Run("should leave unknown tags, and escaped tags, as they are", func(t *testing.T) {
+			renderer := style.NewRenderer()
+
+			actual := renderer.Render(`<FILTER> </info> a < b \<info>`, true)
+			assert.Equal(t, `<FILTER> </info> a < b <info>`, actual)
+		})
+
+		t.Run("should render added styles", func(t *testing.T) {
+			renderer := style.NewRenderer()
+			renderer.AddStyle("warning", style.Style{Foreground: "black", Background: "yellow", Bold: true})
+
+			actual := renderer.Render("<warning>careful</warning>", true)
+			assert.Equal(t, "\x1b[30;43;1mcareful\x1b[0m", actual)
+		})
+	})
+
+	t.Run("AddStyle()", func(t *testing.T) {
+		t.Run("should panic if the style is invalid", func(t *testing.T) {
+			defer func() {
+				assert.True(t, recover() != nil, "Expected a panic.")
+			}()
+
+			style.NewRenderer().AddStyle("warning", style.Style{Foreground: "mauve"})
+		})
+
+		t.Run("should panic if the name couldn't be used in a tag", func(t *testing.T) {
+			defer func() {
+				assert.True(t, recover() != nil, "Expected a panic.")
+			}()
+
+			style.NewRenderer().AddStyle("a/b", style.Style{Bold: true})
+		})
+	})
+}
+
+func TestEscape(t *testing.T) {
+	t.Run("should escape tags so that they're written as they are", func(t *testing.T) {
+		renderer := style.NewRenderer()
+
+		actual := renderer.Render("<info>"+style.Escape("<b>bold</b>")+"</info>", false)
+		assert.Equal(t, "<b>bold</b>", actual)
+	})
+}
@@ -0,0 +1,97 @@
+package style
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// colors maps the names of colors to their ANSI foreground codes. Background codes are 10 higher.
+var colors = map[string]int{
+	"default":        39,
+	"black":          30,
+	"red":            31,
+	"green":          32,
+	"yellow":         33,
+	"blue":           34,
+	"magenta":        35,
+	"cyan":           36,
+	"white":          37,
+	"bright-black":   90,
+	"bright-red":     91,
+	"bright-green":   92,
+	"bright-yellow":  93,
+	"bright-blue":    94,
+	"bright-magenta": 95,
+	"bright-cyan":    96,
+	"bright-white":   97,
+}
+
+// Style is a combination of colors and text attributes. Colors are named, e.g. "red", or
+// "bright-blue", and left unchanged if empty.
+type Style struct {
+	Foreground string
+	Background string
+	Bold       bool
+	Dim        bool
+	Italic     bool
+	Underline  bool
+}
+
+// Builtins gets the built-in styles, keyed by name. A new map is returned each time, so that it can
+// safely be added to.
+func Builtins() map[string]Style {
+	return map[string]Style{
+		"info":    {Foreground: "green"},
+		"comment": {Foreground: "yellow"},
+		"error":   {Foreground: "white", Background: "red"},
+		"b":       {Bold: true},
+		"u":       {Underline: true},
+	}
+}
+
+// Validate checks that the style's colors are known.
+func (s Style) Validate() error {
+	for _, color := range []string{s.Foreground, s.Background} {
+		if _, ok := colors[color]; color != "" && !ok {
+			return fmt.Errorf("Unknown color '%s'", color)
+		}
+	}
+
+	return nil
+}
+
+// sequence gets the ANSI escape sequence that applies the style, if any.
+func (s Style) sequence() string {
+	var codes []string
+
+	if code, ok := colors[s.Foreground]; ok {
+		codes = append(codes, strconv.Itoa(code))
+	}
+
+	if code, ok := colors[s.Background]; ok {
+		codes = append(codes, strconv.Itoa(code+10))
+	}
+
+	attributes := []struct {
+		enabled bool
+		code    string
+	}{
+		{s.Bold, "1"},
+		{s.Dim, "2"},
+		{s.Italic, "3"},
+		{s.Underline, "4"},
+	}
+
+	for _, attribute := range attributes {
+		if attribute.enabled {
+			codes = append(codes, attribute.code)
+		}
+	}
+
+	if len(codes) == 0 {
+		return ""
+	}
+
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
@@ -0,0 +1,37 @@
+package style_test
+
+import (
+	"testing"
+
+	"github.com/eidolon/console/style"
+	"github.com/seeruk/assert"
+)
+
+func TestStyle(t *testing.T) {
+	t.Run("Validate()", func(t *testing.T) {
+		t.Run("should accept known colors", func(t *testing.T) {
+			err := style.Style{Foreground: "bright-cyan", Background: "black"}.Validate()
+			assert.OK(t, err)
+		})
+
+		t.Run("should accept styles without colors", func(t *testing.T) {
+			err := style.Style{Bold: true}.Validate()
+			assert.OK(t, err)
+		})
+
+		t.Run("should error for unknown colors", func(t *testing.T) {
+			err := style.Style{Background: "mauve"}.Validate()
+			assert.NotOK(t, err)
+		})
+	})
+}
+
+func TestBuiltins(t *testing.T) {
+	t.Run("should return a new map each time", func(t *testing.T) {
+		builtins := style.Builtins()
+		delete(builtins, "info")
+
+		_, ok := style.Builtins()["info"]
+		assert.True(t, ok, "Expected built-in styles to be unaffected.")
+	})
+}
@@ -0,0 +1,92 @@
+package console
+
+import (
+	"sort"
+)
+
+// suggest finds the candidates most similar to the given name, by edit distance, for "did you
+// mean" style messages. Candidates that are too different to be a likely typo are left out.
+func suggest(name string, candidates []string) []string {
+	// Allow roughly one mistake for every 3 characters, and always at least one.
+	threshold := len(name) / 3
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	best := threshold + 1
+	var suggestions []string
+
+	for _, candidate := range candidates {
+		distance := editDistance(name, candidate)
+
+		switch {
+		case distance < best:
+			best = distance
+			suggestions = []string{candidate}
+		case distance == best && !containsString(suggestions, candidate):
+			suggestions = append(suggestions, candidate)
+		}
+	}
+
+	sort.Strings(suggestions)
+
+	return suggestions
+}
+
+// editDistance calculates the edit distance between two strings, that is, the number of single
+// character insertions, deletions, substitutions, or swaps of adjacent characters needed to turn one
+// into the other.
+func editDistance(a string, b string) int {
+	ar := []rune(a)
+	br := []rune(b)
+
+	d := make([][]int, len(ar)+1)
+	for i := range d {
+		d[i] = make([]int, len(br)+1)
+		d[i][0] = i
+	}
+
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && ar[i-1] == br[j-2] && ar[i-2] == br[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(ar)][len(br)]
+}
+
+// minInt gets the smallest of the given ints.
+func minInt(first int, rest ...int) int {
+	min := first
+	for _, i := range rest {
+		if i < min {
+			min = i
+		}
+	}
+
+	return min
+}
+
+// containsString reports whether the given slice contains the given string.
+func containsString(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,20 +1,88 @@
 package testing
 
 import (
+	"bytes"
+	"context"
+	"os"
+
 	. "github.com/eidolon/console"
 )
 
+// TestingT is the part of testing.TB that the assertions in this package use.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// CaptureOutput creates an Output that writes to buffers instead of stdout and stderr, so that
+// regular output and diagnostics can be checked independently in tests. If markup is enabled, it's
+// always stripped, regardless of the environment.
+func CaptureOutput() (*Output, *bytes.Buffer, *bytes.Buffer) {
+	writer := &bytes.Buffer{}
+	errWriter := &bytes.Buffer{}
+
+	output := NewOutput(writer)
+	output.ErrorWriter = errWriter
+	output.Color = ColorNever
+
+	return output, writer, errWriter
+}
+
 // RunCommand makes it easier to run a command in a test, by providing all inputs and output, and
 // preparing a command similarly to how it is prepared when run in an application.
 func RunCommand(cmd *Command, def *Definition, in *Input, env []string, out *Output) error {
+	return RunCommandContext(context.Background(), cmd, def, in, env, out)
+}
+
+// RunCommandContext runs a command in a test the same way that RunCommand does, giving the command
+// a context derived from ctx, with the command's timeout applied, if it has one. The command's own
+// hooks are run around it's execution. Like MapInput, only the first problem found with the input is
+// returned, problems mapping input come before unknown input in strict mode.
+func RunCommandContext(ctx context.Context, cmd *Command, def *Definition, in *Input, env []string, out *Output) error {
 	if cmd.Configure != nil {
 		cmd.Configure(def)
 	}
 
-	err := MapInput(def, in, env)
-	if err != nil {
+	if err := MapInput(def, in, env); err != nil {
 		return err
 	}
 
-	return cmd.Execute(in, out)
+	if cmd.Strict {
+		if err := CheckUnknownInput(def, in); err != nil {
+			return err.(MappingErrors)[0]
+		}
+	}
+
+	if cmd.Validate != nil {
+		if err := cmd.Validate(in); err != nil {
+			return err
+		}
+	}
+
+	return ExecuteCommand(ctx, cmd, in, out)
+}
+
+// AssertCompatible fails a test for each breaking change between the command-line interface
+// recorded in the snapshot file at the given path, and the given application's current one. If the
+// snapshot doesn't exist yet, it's recorded instead, so that it can be committed.
+func AssertCompatible(t TestingT, app *Application, path string) {
+	t.Helper()
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := WriteSnapshot(app, path); err != nil {
+			t.Errorf("console: Failed to write snapshot: %v", err)
+		}
+
+		return
+	}
+
+	report, err := CheckCompatibility(app, path)
+	if err != nil {
+		t.Errorf("console: Failed to check compatibility: %v", err)
+		return
+	}
+
+	for _, change := range report.Breaking {
+		t.Errorf("console: Breaking change: %s", change)
+	}
 }
@@ -0,0 +1,93 @@
+package console
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eidolon/console/parameters"
+)
+
+// maskedValue is shown in place of values that may be secret.
+const maskedValue = "(masked)"
+
+// DescribeValues describes the effective value of each argument and option in a Definition, and
+// where each value was set from. Input should be mapped before values are described. Hidden
+// arguments and options are left out. Values set from the environment or config files may hold
+// secrets, e.g. tokens, so they're masked unless reveal is true.
+func DescribeValues(definition *Definition, reveal bool) string {
+	var keys []string
+	var values []string
+	var sources []string
+
+	for _, arg := range visibleArguments(definition.Arguments()) {
+		keys = append(keys, arg.Name)
+		values = append(values, describeValue(arg.Value, arg.Source, reveal))
+		sources = append(sources, describeValueSource(arg.Source, arg.Origin))
+	}
+
+	numArgs := len(keys)
+
+	for _, opt := range visibleOptions(definition.Options()) {
+		keys = append(keys, describeOptionName(opt))
+		values = append(values, describeValue(opt.Value, opt.Source, reveal))
+		sources = append(sources, describeValueSource(opt.Source, opt.Origin))
+	}
+
+	// Find the maximum width of the keys and values for spacing.
+	var keyWidth int
+	var valueWidth int
+	for i := range keys {
+		if len(keys[i])+2 > keyWidth {
+			keyWidth = len(keys[i]) + 2
+		}
+
+		if len(values[i])+2 > valueWidth {
+			valueWidth = len(values[i]) + 2
+		}
+	}
+
+	var desc string
+
+	for i := range keys {
+		if i == 0 && numArgs > 0 {
+			desc += "ARGUMENTS:\n"
+		}
+
+		if i == numArgs {
+			if numArgs > 0 {
+				desc += "\n"
+			}
+
+			desc += "OPTIONS:\n"
+		}
+
+		desc += fmt.Sprintf(
+			"  %s%s%s%s%s\n",
+			keys[i],
+			strings.Repeat(" ", keyWidth-len(keys[i])),
+			values[i],
+			strings.Repeat(" ", valueWidth-len(values[i])),
+			sources[i],
+		)
+	}
+
+	return desc
+}
+
+// describeValue describes a value set from the given source, masking it if it may be secret.
+func describeValue(value parameters.Value, source parameters.ValueSource, reveal bool) string {
+	if !reveal && (source == parameters.SourceEnv || source == parameters.SourceConfig) {
+		return maskedValue
+	}
+
+	return fmt.Sprintf("%q", value.String())
+}
+
+// describeValueSource describes where a value was set from, e.g. `(env: NAME)`.
+func describeValueSource(source parameters.ValueSource, origin string) string {
+	if origin == "" {
+		return fmt.Sprintf("(%s)", source)
+	}
+
+	return fmt.Sprintf("(%s: %s)", source, origin)
+}
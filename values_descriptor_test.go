@@ -0,0 +1,123 @@
+package console_test
+
+import (
+	"testing"
+
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+	"github.com/seeruk/assert"
+)
+
+func TestDescribeValues(t *testing.T) {
+	t.Run("should describe each value and where it was set from", func(t *testing.T) {
+		var name string
+		var greeting string
+		var verbose bool
+
+		definition := console.NewDefinition()
+		definition.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewStringValue(&name),
+			Spec:  "NAME",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value:   parameters.NewStringValue(&greeting),
+			Spec:    "-g, --greeting=GREETING",
+			Default: "Hello",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value:  parameters.NewBoolValue(&verbose),
+			Spec:   "-v",
+			EnvVar: "VERBOSE",
+		})
+
+		err := console.MapInput(definition, console.ParseInput([]string{"Bob"}), []string{"VERBOSE=1"})
+		assert.OK(t, err)
+
+		expected := "ARGUMENTS:\n" +
+			"  NAME        \"Bob\"    (input: argument 1)\n" +
+			"\n" +
+			"OPTIONS:\n" +
+			"  --greeting  \"Hello\"  (default)\n" +
+			"  -v          \"true\"   (env: VERBOSE)\n"
+
+		assert.Equal(t, expected, console.DescribeValues(definition, true))
+	})
+
+	t.Run("should mask values from the environment and config files unless revealed", func(t *testing.T) {
+		var user string
+		var token string
+		var region string
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewStringValue(&user),
+			Spec:  "--user=USER",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value:  parameters.NewStringValue(&token),
+			Spec:   "--token=TOKEN",
+			EnvVar: "TOKEN",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewStringValue(&region),
+			Spec:  "--region=REGION",
+		})
+
+		input := console.ParseInput([]string{"--user=bob"})
+		input.Config = []console.InputConfigValue{
+			{Name: "region", Key: "region", Values: []string{"eu"}, File: "config.yaml", Line: 1},
+		}
+
+		err := console.MapInput(definition, input, []string{"TOKEN=secret"})
+		assert.OK(t, err)
+
+		expected := "OPTIONS:\n" +
+			"  --user    \"bob\"     (input: --user)\n" +
+			"  --token   (masked)  (env: TOKEN)\n" +
+			"  --region  (masked)  (config: config.yaml:1, key 'region')\n"
+
+		assert.Equal(t, expected, console.DescribeValues(definition, false))
+	})
+
+	t.Run("should omit hidden arguments and options", func(t *testing.T) {
+		var name string
+		var token string
+
+		definition := console.NewDefinition()
+		definition.AddArgument(console.ArgumentDefinition{
+			Value:  parameters.NewStringValue(&name),
+			Spec:   "[NAME]",
+			Hidden: true,
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value:  parameters.NewStringValue(&token),
+			Spec:   "--token=TOKEN",
+			Hidden: true,
+		})
+
+		err := console.MapInput(definition, console.ParseInput([]string{"bob", "--token=secret"}), []string{})
+		assert.OK(t, err)
+
+		assert.Equal(t, "", console.DescribeValues(definition, true))
+	})
+
+	t.Run("should omit the arguments section if there are no arguments", func(t *testing.T) {
+		var s1 string
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value: parameters.NewStringValue(&s1),
+			Spec:  "--s1=S1",
+		})
+
+		err := console.MapInput(definition, console.ParseInput([]string{}), []string{})
+		assert.OK(t, err)
+
+		assert.Equal(t, "OPTIONS:\n  --s1  \"\"  (none)\n", console.DescribeValues(definition, false))
+	})
+}